@@ -0,0 +1,92 @@
+package rangeset
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// An AlignedBlock is a Range of 2^Log2Size elements starting at Base, where
+// Base is aligned to 2^Log2Size, like a CIDR prefix or a buddy allocator
+// block.
+//
+// Base is aligned if its distance from the minimum value of E is a multiple
+// of 2^Log2Size. For unsigned E, that is Base itself being a multiple. For
+// signed E, it is the same as the two's complement bit pattern of Base being
+// a multiple (e.g. -8 is aligned to 8), except for a block as wide as E:
+// such a block covers every E, and its Base must be the minimum value of E
+// (e.g. -128 for int8).
+type AlignedBlock[E Elem] struct {
+	Base     E
+	Log2Size int
+}
+
+// AlignedBlocks returns the fewest AlignedBlocks that cover exactly set, in
+// ascending order.
+func (set RangeSet[E]) AlignedBlocks() []AlignedBlock[E] {
+	var blocks []AlignedBlock[E]
+
+	// Taking, at each step, the largest block that is aligned at lo and does
+	// not go past hi gives the minimal cover. Offsets from the minimum value
+	// of E have the same alignment as the elements themselves, since the
+	// minimum value is either zero or -2^(bitSize-1).
+
+	for _, r := range set {
+		lo, hi := offsetOf(r.Low), offsetOf(r.High)
+
+		for lo < hi {
+			k := min(bits.TrailingZeros64(lo), bits.Len64(hi-lo)-1)
+			blocks = append(blocks, AlignedBlock[E]{elemOf[E](lo), k})
+			lo += 1 << k
+		}
+	}
+
+	return blocks
+}
+
+var (
+	errBlockSize  = errors.New("block size out of range")
+	errMisaligned = errors.New("base is not aligned to block size")
+)
+
+// FromAlignedBlocks creates a RangeSet from arbitrary AlignedBlocks, which
+// can be in any order and overlap or adjoin each other. It is the inverse
+// of AlignedBlocks.
+//
+// Since a RangeSet cannot hold the maximum value of E, it is ignored if
+// covered by a block, e.g. the block {Base: 0, Log2Size: 8} of uint8 yields
+// the same set as Universal.
+//
+// FromAlignedBlocks returns an error if a Log2Size is negative or greater
+// than the bit size of E, or if a Base is not aligned.
+func FromAlignedBlocks[E Elem](blocks []AlignedBlock[E]) (RangeSet[E], error) {
+	var b Builder[E]
+
+	limit := offsetOf(maxOf[E]())
+
+	// Alignment is checked on offsets, following the rule documented on
+	// AlignedBlock, which AlignedBlocks also follows.
+
+	for _, block := range blocks {
+		if block.Log2Size < 0 || block.Log2Size > bitSize[E]() {
+			return nil, fmt.Errorf("rangeset: block %v/%v: %w", block.Base, block.Log2Size, errBlockSize)
+		}
+
+		lo := offsetOf(block.Base)
+		mask := ^uint64(0) >> (64 - block.Log2Size) // Shifting by 64 gives zero.
+
+		if lo&mask != 0 {
+			return nil, fmt.Errorf("rangeset: block %v/%v: %w", block.Base, block.Log2Size, errMisaligned)
+		}
+
+		last := lo | mask
+		if last == limit {
+			b.AddRange(block.Base, maxOf[E]())
+			continue
+		}
+
+		b.AddRange(block.Base, elemOf[E](last+1))
+	}
+
+	return b.RangeSet(), nil
+}
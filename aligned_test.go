@@ -0,0 +1,148 @@
+package rangeset_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestAlignedBlocks(t *testing.T) {
+	type B = AlignedBlock[uint8]
+
+	testCases := []struct {
+		Input    RangeSet[uint8]
+		Expected []B
+	}{
+		{RangeSet[uint8]{}, nil},
+		{RangeSet[uint8]{{0, 1}}, []B{{0, 0}}},
+		{RangeSet[uint8]{{16, 32}}, []B{{16, 4}}},
+		{RangeSet[uint8]{{5, 21}}, []B{{5, 0}, {6, 1}, {8, 3}, {16, 2}, {20, 0}}},
+		{RangeSet[uint8]{{1, 3}, {4, 8}}, []B{{1, 0}, {2, 0}, {4, 2}}},
+		{
+			Universal[uint8](),
+			[]B{{0, 7}, {128, 6}, {192, 5}, {224, 4}, {240, 3}, {248, 2}, {252, 1}, {254, 0}},
+		},
+	}
+
+	for i, c := range testCases {
+		if result := c.Input.AlignedBlocks(); !equalBlocks(result, c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, result)
+		}
+	}
+
+	{
+		result := Universal[int8]().AlignedBlocks()
+		expected := []AlignedBlock[int8]{{-128, 7}, {0, 6}, {64, 5}, {96, 4}, {112, 3}, {120, 2}, {124, 1}, {126, 0}}
+
+		if !equalBlocks(result, expected) {
+			t.Fatalf("want %v, but got %v", expected, result)
+		}
+	}
+
+	{
+		result := Universal[uint64]().AlignedBlocks()
+
+		if len(result) != 64 || result[0] != (AlignedBlock[uint64]{0, 63}) ||
+			result[63] != (AlignedBlock[uint64]{math.MaxUint64 - 1, 0}) {
+			t.Fatalf("unexpected blocks %v", result)
+		}
+	}
+}
+
+func TestFromAlignedBlocks(t *testing.T) {
+	type B = AlignedBlock[int8]
+
+	testCases := []struct {
+		Input    []B
+		Expected RangeSet[int8]
+	}{
+		{nil, RangeSet[int8]{}},
+		{[]B{{16, 2}, {-8, 3}, {0, 0}, {20, 2}}, RangeSet[int8]{{-8, 1}, {16, 24}}},
+		{[]B{{-128, 8}}, Universal[int8]()},
+		{[]B{{64, 6}, {127, 0}}, RangeSet[int8]{{64, 127}}},
+		{[]B{{127, 0}}, RangeSet[int8]{}},
+	}
+
+	for i, c := range testCases {
+		s, err := FromAlignedBlocks(c.Input)
+		if err != nil {
+			t.Fail()
+			t.Logf("Case %v: unexpected error: %v", i, err)
+
+			continue
+		}
+
+		if !s.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, s)
+		}
+	}
+
+	// A block as wide as int8 must start at -128, not at 0.
+	for i, input := range [][]B{{{0, -1}}, {{0, 9}}, {{4, 3}}, {{-127, 7}}, {{1, 8}}, {{0, 8}}} {
+		if _, err := FromAlignedBlocks(input); err == nil {
+			t.Fail()
+			t.Logf("Case %v: want an error for %v, but got nil", i, input)
+		}
+	}
+}
+
+func TestFromAlignedBlocks_fullWidth(t *testing.T) {
+	s, err := FromAlignedBlocks([]AlignedBlock[uint8]{{0, 8}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !s.Equal(Universal[uint8]()) {
+		t.Fatalf("want %v, but got %v", Universal[uint8](), s)
+	}
+}
+
+func TestAlignedBlocks_random(t *testing.T) {
+	rand := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		var s RangeSet[int16]
+
+		for j := rand.Intn(8); j > 0; j-- {
+			lo := int16(rand.Intn(1 << 16))
+			s.AddRange(lo, lo+int16(rand.Intn(1000)))
+		}
+
+		blocks := s.AlignedBlocks()
+
+		for j, b := range blocks {
+			// No two consecutive blocks of the same size should be mergeable.
+			if j > 0 && blocks[j-1].Log2Size == b.Log2Size &&
+				uint16(blocks[j-1].Base)>>(b.Log2Size+1) == uint16(b.Base)>>(b.Log2Size+1) {
+				t.Fatalf("Case %v: %v and %v can be merged", i, blocks[j-1], b)
+			}
+		}
+
+		r, err := FromAlignedBlocks(blocks)
+		if err != nil {
+			t.Fatalf("Case %v: %v", i, err)
+		}
+
+		if !r.Equal(s) {
+			t.Fatalf("Case %v: want %v, but got %v", i, s, r)
+		}
+	}
+}
+
+func equalBlocks[E Elem](b1, b2 []AlignedBlock[E]) bool {
+	if len(b1) != len(b2) {
+		return false
+	}
+
+	for i := range b1 {
+		if b1[i] != b2[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,149 @@
+package rangeset
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrDoubleFree is returned by Allocator.Free when freeing a range that is,
+// at least partially, already free.
+var ErrDoubleFree = errors.New("rangeset: double free")
+
+// An Allocator hands out ranges of E from a pool of free elements, which
+// makes it suitable for allocating IDs, ports, disk extents and the like.
+//
+// The zero value for an Allocator has nothing to allocate. Use Free or
+// NewAllocator to fill the pool.
+type Allocator[E Elem] struct {
+	free RangeSet[E]
+}
+
+// NewAllocator creates an Allocator whose pool of free elements is a copy
+// of free.
+func NewAllocator[E Elem](free RangeSet[E]) *Allocator[E] {
+	return &Allocator[E]{append(RangeSet[E](nil), free...)}
+}
+
+// Available returns a copy of the pool of free elements.
+func (a *Allocator[E]) Available() RangeSet[E] {
+	return append(RangeSet[E](nil), a.free...)
+}
+
+// Allocate allocates n consecutive elements from the first free Range that
+// is large enough, and returns the first one.
+//
+// If n <= 0 or there is no free Range large enough, Allocate returns the
+// zero value and false.
+func (a *Allocator[E]) Allocate(n E) (E, bool) {
+	if n <= 0 {
+		return 0, false
+	}
+
+	for _, r := range a.free {
+		if rangeSize(r) >= uint64(n) {
+			a.free.DeleteRange(r.Low, r.Low+n)
+			return r.Low, true
+		}
+	}
+
+	return 0, false
+}
+
+// AllocateBestFit allocates n consecutive elements from the smallest free
+// Range that is large enough, and returns the first one. Ties are broken
+// in favor of the lowest Range.
+//
+// If n <= 0 or there is no free Range large enough, AllocateBestFit
+// returns the zero value and false.
+func (a *Allocator[E]) AllocateBestFit(n E) (E, bool) {
+	if n <= 0 {
+		return 0, false
+	}
+
+	best := -1
+
+	var bestSize uint64
+
+	for i, r := range a.free {
+		if size := rangeSize(r); size >= uint64(n) && (best < 0 || size < bestSize) {
+			best, bestSize = i, size
+
+			if size == uint64(n) {
+				break
+			}
+		}
+	}
+
+	if best < 0 {
+		return 0, false
+	}
+
+	lo := a.free[best].Low
+	a.free.DeleteRange(lo, lo+n)
+
+	return lo, true
+}
+
+// AllocateAligned allocates n consecutive elements, the first of which is
+// a multiple of align, from the first free Range that can hold them, and
+// returns the first one.
+//
+// If n <= 0, align <= 0 or there is no suitable free Range,
+// AllocateAligned returns the zero value and false.
+func (a *Allocator[E]) AllocateAligned(n, align E) (E, bool) {
+	if n <= 0 || align <= 0 {
+		return 0, false
+	}
+
+	for _, r := range a.free {
+		var shift E
+
+		if rem := r.Low % align; rem < 0 {
+			shift = -rem
+		} else if rem > 0 {
+			shift = align - rem
+		}
+
+		if size := rangeSize(r); size > uint64(shift) && size-uint64(shift) >= uint64(n) {
+			lo := r.Low + shift
+			a.free.DeleteRange(lo, lo+n)
+
+			return lo, true
+		}
+	}
+
+	return 0, false
+}
+
+// AllocateAt allocates every element in range [lo, hi).
+//
+// If lo >= hi or some element in range [lo, hi) is not free, AllocateAt
+// allocates nothing and returns false.
+func (a *Allocator[E]) AllocateAt(lo, hi E) bool {
+	if !a.free.ContainsRange(lo, hi) {
+		return false
+	}
+
+	a.free.DeleteRange(lo, hi)
+
+	return true
+}
+
+// Free returns every element in range [lo, hi) to the pool.
+//
+// If some element in range [lo, hi) is already free, Free returns an error
+// wrapping ErrDoubleFree and leaves the pool unchanged.
+func (a *Allocator[E]) Free(lo, hi E) error {
+	s := a.free
+
+	i := sort.Search(len(s), func(i int) bool { return s[i].High > lo })
+
+	if i < len(s) && s[i].Low < hi && lo < hi {
+		return fmt.Errorf("%w: [%v,%v) overlaps free range [%v,%v)", ErrDoubleFree, lo, hi, s[i].Low, s[i].High)
+	}
+
+	a.free.AddRange(lo, hi)
+
+	return nil
+}
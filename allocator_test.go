@@ -0,0 +1,112 @@
+package rangeset_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestAllocator(t *testing.T) {
+	type E int
+
+	a := NewAllocator(RangeSet[E]{{0, 4}, {10, 12}, {20, 30}})
+
+	type result struct {
+		V  E
+		OK bool
+	}
+
+	wrap := func(v E, ok bool) result { return result{v, ok} }
+
+	testCases := []struct {
+		Result, Expected result
+	}{
+		{wrap(a.Allocate(3)), result{0, true}},
+		{wrap(a.Allocate(2)), result{10, true}},
+		{wrap(a.Allocate(2)), result{20, true}},
+		{wrap(a.Allocate(0)), result{0, false}},
+		{wrap(a.Allocate(100)), result{0, false}},
+		{wrap(a.AllocateBestFit(1)), result{3, true}},
+		{wrap(a.AllocateAligned(4, 8)), result{24, true}},
+		{wrap(a.AllocateAligned(4, 8)), result{0, false}},
+		{wrap(a.AllocateAligned(2, 0)), result{0, false}},
+	}
+
+	for i, c := range testCases {
+		if c.Result != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+
+	if expected := (RangeSet[E]{{22, 24}, {28, 30}}); !a.Available().Equal(expected) {
+		t.Fatalf("want %v, but got %v", expected, a.Available())
+	}
+}
+
+func TestAllocator_bestFit(t *testing.T) {
+	type E uint16
+
+	a := NewAllocator(RangeSet[E]{{0, 8}, {10, 13}, {20, 24}, {30, 33}})
+
+	if v, ok := a.AllocateBestFit(3); v != 10 || !ok {
+		t.Fatalf("want (10, true), but got (%v, %v)", v, ok)
+	}
+
+	if v, ok := a.AllocateBestFit(4); v != 20 || !ok {
+		t.Fatalf("want (20, true), but got (%v, %v)", v, ok)
+	}
+
+	if v, ok := a.AllocateBestFit(5); v != 0 || !ok {
+		t.Fatalf("want (0, true), but got (%v, %v)", v, ok)
+	}
+}
+
+func TestAllocator_alignedNegative(t *testing.T) {
+	type E int8
+
+	a := NewAllocator(RangeSet[E]{{-13, -1}})
+
+	if v, ok := a.AllocateAligned(4, 4); v != -12 || !ok {
+		t.Fatalf("want (-12, true), but got (%v, %v)", v, ok)
+	}
+
+	if v, ok := a.AllocateAligned(4, 4); v != -8 || !ok {
+		t.Fatalf("want (-8, true), but got (%v, %v)", v, ok)
+	}
+
+	if v, ok := a.AllocateAligned(4, 4); ok {
+		t.Fatalf("want (0, false), but got (%v, %v)", v, ok)
+	}
+}
+
+func TestAllocator_free(t *testing.T) {
+	type E int
+
+	var a Allocator[E]
+
+	if err := a.Free(0, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	if !a.AllocateAt(2, 5) {
+		t.Fatal("AllocateAt(2, 5) failed")
+	}
+
+	if a.AllocateAt(4, 6) {
+		t.Fatal("AllocateAt(4, 6) succeeded on a partially allocated range")
+	}
+
+	if err := a.Free(4, 6); !errors.Is(err, ErrDoubleFree) {
+		t.Fatalf("want ErrDoubleFree, but got %v", err)
+	}
+
+	if err := a.Free(2, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := (RangeSet[E]{{0, 10}}); !a.Available().Equal(expected) {
+		t.Fatalf("want %v, but got %v", expected, a.Available())
+	}
+}
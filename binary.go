@@ -0,0 +1,207 @@
+package rangeset
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// The binary form of a RangeSet is a sequence of uvarints:
+//
+//	n                number of Ranges
+//	low[0]           offset of the first Low from the minimum value of E
+//	high[0]-low[0]-1
+//	low[1]-high[0]-1
+//	high[1]-low[1]-1
+//	...
+//
+// Since Ranges in a RangeSet are neither empty nor adjacent, every delta is
+// stored minus one, which keeps dense sets of small Ranges down to a couple
+// of bytes per Range.
+
+var errCorrupt = errors.New("rangeset: corrupt binary data")
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (set RangeSet[E]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := NewEncoder[E](&buf).Encode(set); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (set *RangeSet[E]) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+
+	var s RangeSet[E]
+
+	if err := NewDecoder[E](r).Decode(&s); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF // Empty data is not a valid encoding.
+		}
+
+		return err
+	}
+
+	if r.Len() != 0 {
+		return errCorrupt
+	}
+
+	*set = s
+
+	return nil
+}
+
+// An Encoder writes RangeSets in binary form to an output stream.
+type Encoder[E Elem] struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewEncoder returns a new Encoder that writes to w.
+func NewEncoder[E Elem](w io.Writer) *Encoder[E] {
+	return &Encoder[E]{w: w}
+}
+
+// Encode writes the binary form of set to the stream.
+//
+// Encode returns an error if set is not a valid RangeSet.
+func (enc *Encoder[E]) Encode(set RangeSet[E]) error {
+	if err := set.Validate(); err != nil {
+		return err
+	}
+
+	const flushSize = 4096
+
+	b := appendUvarint(enc.buf[:0], uint64(len(set)))
+
+	var prev uint64
+
+	for i, r := range set {
+		lo, hi := offsetOf(r.Low), offsetOf(r.High)
+
+		if i == 0 {
+			b = appendUvarint(b, lo)
+		} else {
+			b = appendUvarint(b, lo-prev-1)
+		}
+
+		b = appendUvarint(b, hi-lo-1)
+		prev = hi
+
+		if len(b) >= flushSize {
+			if _, err := enc.w.Write(b); err != nil {
+				return err
+			}
+
+			b = b[:0]
+		}
+	}
+
+	enc.buf = b
+
+	if len(b) > 0 {
+		if _, err := enc.w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// A Decoder reads RangeSets in binary form from an input stream.
+type Decoder[E Elem] struct {
+	r io.ByteReader
+}
+
+// NewDecoder returns a new Decoder that reads from r.
+//
+// If r does not also implement io.ByteReader, the Decoder wraps it in
+// a bufio.Reader and may read data from r beyond the RangeSets requested.
+func NewDecoder[E Elem](r io.Reader) *Decoder[E] {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	return &Decoder[E]{r: br}
+}
+
+// Decode reads the next RangeSet from the stream and stores it in *set.
+//
+// Decode returns io.EOF if there is no more data, or an error if the data
+// does not describe a valid RangeSet of E.
+func (dec *Decoder[E]) Decode(set *RangeSet[E]) error {
+	n, err := binary.ReadUvarint(dec.r)
+	if err != nil {
+		return err
+	}
+
+	const maxPrealloc = 1024
+
+	prealloc := n
+	if prealloc > maxPrealloc {
+		prealloc = maxPrealloc
+	}
+
+	s := make(RangeSet[E], 0, prealloc)
+
+	limit := offsetOf(maxOf[E]())
+
+	var prev uint64
+
+	for i := uint64(0); i < n; i++ {
+		d1, err := readUvarint(dec.r)
+		if err != nil {
+			return err
+		}
+
+		d2, err := readUvarint(dec.r)
+		if err != nil {
+			return err
+		}
+
+		lo := d1
+
+		if i > 0 {
+			if d1 >= limit-prev {
+				return errCorrupt
+			}
+
+			lo = prev + d1 + 1
+		}
+
+		if lo >= limit || d2 >= limit-lo {
+			return errCorrupt
+		}
+
+		hi := lo + d2 + 1
+		s = append(s, Range[E]{elemOf[E](lo), elemOf[E](hi)})
+		prev = hi
+	}
+
+	*set = s
+
+	return nil
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var a [binary.MaxVarintLen64]byte
+	return append(b, a[:binary.PutUvarint(a[:], v)]...)
+}
+
+// readUvarint is like binary.ReadUvarint, but reports an unexpected end of
+// data as io.ErrUnexpectedEOF.
+func readUvarint(r io.ByteReader) (uint64, error) {
+	v, err := binary.ReadUvarint(r)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return v, err
+}
@@ -0,0 +1,152 @@
+package rangeset_test
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	type E int8
+
+	testCases := []RangeSet[E]{
+		{},
+		{{1, 2}},
+		{{1, 6}, {7, 8}, {9, 13}},
+		{{math.MinInt8, -100}, {0, math.MaxInt8}},
+		Universal[E](),
+	}
+
+	for i, s := range testCases {
+		b, err := s.MarshalBinary()
+		if err != nil {
+			t.Fail()
+			t.Logf("Case %v: unexpected error: %v", i, err)
+
+			continue
+		}
+
+		var r RangeSet[E]
+
+		if err := r.UnmarshalBinary(b); err != nil {
+			t.Fail()
+			t.Logf("Case %v: unexpected error: %v", i, err)
+
+			continue
+		}
+
+		if !r.Equal(s) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, s, r)
+		}
+	}
+}
+
+func TestMarshalBinary_compact(t *testing.T) {
+	type E uint64
+
+	var s RangeSet[E]
+
+	for i := E(1 << 40); i < 1<<40+3000; i += 3 {
+		s.AddRange(i, i+2)
+	}
+
+	b, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if max := 2*len(s) + 16; len(b) > max {
+		t.Fatalf("want at most %v bytes, but got %v", max, len(b))
+	}
+}
+
+func TestMarshalBinary_invalid(t *testing.T) {
+	type E int
+
+	if _, err := (RangeSet[E]{{5, 7}, {1, 3}}).MarshalBinary(); err == nil {
+		t.Fatal("want an error for unsorted set, but got nil")
+	}
+}
+
+func TestUnmarshalBinary_error(t *testing.T) {
+	type E uint8
+
+	inputs := [][]byte{
+		nil,
+		{},
+		{1},
+		{1, 5},
+		{1, 0, 0xff, 0x01},
+		{1, 0xff, 0x01, 0},
+		{2, 0, 0, 0xfd, 0x01, 0},
+		{1, 0, 0, 0},
+	}
+
+	for i, input := range inputs {
+		var s RangeSet[E]
+
+		switch err := s.UnmarshalBinary(input); err {
+		case nil:
+			t.Fail()
+			t.Logf("Case %v: want an error for %v, but got %v", i, input, s)
+		case io.EOF:
+			t.Fail()
+			t.Logf("Case %v: want an error other than io.EOF for %v", i, input)
+		}
+	}
+
+	var s RangeSet[E]
+
+	if err := s.UnmarshalBinary([]byte{1, 0xfe, 0x01, 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := (RangeSet[E]{{254, 255}}); !s.Equal(expected) {
+		t.Fatalf("want %v, but got %v", expected, s)
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	type E int32
+
+	sets := []RangeSet[E]{
+		{{-5, 5}},
+		{},
+		{{1, 2}, {3, 4}, {math.MaxInt32 - 1, math.MaxInt32}},
+	}
+
+	var buf bytes.Buffer
+
+	enc := NewEncoder[E](&buf)
+
+	for _, s := range sets {
+		if err := enc.Encode(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dec := NewDecoder[E](io.MultiReader(&buf))
+
+	for i, s := range sets {
+		var r RangeSet[E]
+
+		if err := dec.Decode(&r); err != nil {
+			t.Fatal(err)
+		}
+
+		if !r.Equal(s) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, s, r)
+		}
+	}
+
+	var r RangeSet[E]
+
+	if err := dec.Decode(&r); err != io.EOF {
+		t.Fatalf("want io.EOF, but got %v", err)
+	}
+}
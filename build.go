@@ -0,0 +1,119 @@
+package rangeset
+
+import "slices"
+
+// FromRanges creates a RangeSet from arbitrary Ranges, which can be in any
+// order, overlap or adjoin each other. Empty Ranges are ignored.
+//
+// FromRanges takes O(n log n) time and does not modify ranges.
+func FromRanges[E Elem](ranges []Range[E]) RangeSet[E] {
+	return normalize(slices.Clone(RangeSet[E](ranges)))
+}
+
+// FromElements creates a RangeSet from arbitrary elements, which can be in
+// any order and contain duplicates.
+//
+// Since a RangeSet cannot hold the maximum value of E, it is ignored if
+// present in elems.
+//
+// FromElements takes O(n log n) time and does not modify elems.
+func FromElements[E Elem](elems []E) RangeSet[E] {
+	sorted := slices.Clone(elems)
+	slices.Sort(sorted)
+
+	return FromSortedElements(sorted)
+}
+
+// FromSortedElements creates a RangeSet from elements sorted in ascending
+// order, which can contain duplicates. Runs of consecutive elements are
+// detected and stored as single Ranges.
+//
+// Since a RangeSet cannot hold the maximum value of E, it is ignored if
+// present in elems.
+//
+// FromSortedElements takes O(n) time. If elems are not sorted, the result
+// is undefined.
+func FromSortedElements[E Elem](elems []E) RangeSet[E] {
+	var set RangeSet[E]
+
+	for _, v := range elems {
+		if v == maxOf[E]() {
+			break
+		}
+
+		if n := len(set); n > 0 && v <= set[n-1].High {
+			if v == set[n-1].High {
+				set[n-1].High++
+			}
+
+			continue
+		}
+
+		set = append(set, Range[E]{v, v + 1})
+	}
+
+	return set
+}
+
+// A Builder builds a RangeSet from Ranges and elements added in any order.
+// Adding to a Builder takes amortized O(1) time; sorting and merging is
+// done once, when the RangeSet is requested.
+//
+// The zero value for a Builder is ready to use.
+type Builder[E Elem] struct {
+	ranges RangeSet[E]
+	sorted bool // Whether ranges is known to be a valid RangeSet.
+}
+
+// Add adds a single element.
+func (b *Builder[E]) Add(v E) {
+	b.AddRange(v, v+1)
+}
+
+// AddRange adds range [lo, hi).
+func (b *Builder[E]) AddRange(lo, hi E) {
+	if lo >= hi {
+		return
+	}
+
+	s := b.ranges
+
+	if len(s) == 0 {
+		b.sorted = true
+	}
+
+	if n := len(s); n > 0 && b.sorted {
+		last := &s[n-1]
+
+		// Merge in place while Ranges come in ascending order.
+		switch {
+		case lo > last.High:
+		case lo >= last.Low:
+			last.High = max(last.High, hi)
+			return
+		default:
+			b.sorted = false
+		}
+	}
+
+	b.ranges = append(s, Range[E]{lo, hi})
+}
+
+// Len returns the number of Ranges added so far, before merging.
+func (b *Builder[E]) Len() int {
+	return len(b.ranges)
+}
+
+// RangeSet returns the RangeSet built from everything added so far, and
+// resets b to empty.
+func (b *Builder[E]) RangeSet() RangeSet[E] {
+	s := b.ranges
+
+	if !b.sorted {
+		s = normalize(s)
+	}
+
+	*b = Builder[E]{}
+
+	return s
+}
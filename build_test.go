@@ -0,0 +1,104 @@
+package rangeset_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestFromRanges(t *testing.T) {
+	type E int
+
+	input := []Range[E]{{9, 12}, {1, 4}, {3, 5}, {7, 7}, {12, 13}, {20, 15}}
+
+	testCases := []struct {
+		Result, Expected RangeSet[E]
+	}{
+		{FromRanges(input), RangeSet[E]{{1, 5}, {9, 13}}},
+		{FromRanges([]Range[E]{}), RangeSet[E]{}},
+		{FromRanges[E](nil), RangeSet[E]{}},
+		{RangeSet[E](input[:2]), RangeSet[E]{{9, 12}, {1, 4}}},
+	}
+
+	for i, c := range testCases {
+		if !c.Result.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
+
+func TestFromElements(t *testing.T) {
+	type E int8
+
+	testCases := []struct {
+		Result, Expected RangeSet[E]
+	}{
+		{FromElements([]E{5, 1, 2, 3, 7, 2, 6, -1}), RangeSet[E]{{-1, 0}, {1, 4}, {5, 8}}},
+		{FromElements([]E{math.MaxInt8, math.MaxInt8 - 1}), RangeSet[E]{{math.MaxInt8 - 1, math.MaxInt8}}},
+		{FromElements([]E{}), RangeSet[E]{}},
+		{FromSortedElements([]E{1, 1, 2, 4, 4, 5, 9}), RangeSet[E]{{1, 3}, {4, 6}, {9, 10}}},
+		{FromSortedElements([]E{math.MinInt8, math.MinInt8 + 1}), RangeSet[E]{{math.MinInt8, math.MinInt8 + 2}}},
+	}
+
+	for i, c := range testCases {
+		if !c.Result.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	type E int
+
+	var b Builder[E]
+
+	b.AddRange(1, 3)
+	b.AddRange(2, 5)
+	b.Add(5)
+	b.AddRange(8, 9)
+	b.AddRange(9, 9)
+
+	if b.Len() != 2 {
+		t.Fatalf("want 2 Ranges after in-order additions, but got %v", b.Len())
+	}
+
+	b.AddRange(0, 2)
+	b.Add(7)
+
+	if s, expected := b.RangeSet(), (RangeSet[E]{{0, 6}, {7, 9}}); !s.Equal(expected) {
+		t.Fatalf("want %v, but got %v", expected, s)
+	}
+
+	if s := b.RangeSet(); len(s) != 0 {
+		t.Fatalf("want an empty set after reset, but got %v", s)
+	}
+}
+
+func TestBuilder_random(t *testing.T) {
+	type E int
+
+	rng := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 200; n++ {
+		var (
+			b        Builder[E]
+			expected RangeSet[E]
+		)
+
+		for i := rng.Intn(30); i > 0; i-- {
+			lo := E(rng.Intn(100))
+			hi := lo + E(rng.Intn(10))
+
+			b.AddRange(lo, hi)
+			expected.AddRange(lo, hi)
+		}
+
+		if s := b.RangeSet(); !s.Equal(expected) {
+			t.Fatalf("want %v, but got %v", expected, s)
+		}
+	}
+}
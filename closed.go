@@ -0,0 +1,238 @@
+package rangeset
+
+import (
+	"iter"
+	"math"
+	"math/big"
+)
+
+// A ClosedRangeSet is a set of E that, unlike RangeSet, can hold every
+// value of E, including the maximum one.
+//
+// Internally, a ClosedRangeSet is a RangeSet plus a flag telling whether
+// the maximum value of E is in the set. Ranges taken and reported by
+// ClosedRangeSet methods are closed intervals, i.e. both ends are
+// inclusive.
+//
+// The zero value for a ClosedRangeSet is an empty set.
+type ClosedRangeSet[E Elem] struct {
+	set    RangeSet[E] // Every element except the maximum value of E.
+	hasMax bool        // Whether the maximum value of E is in the set.
+}
+
+// FromClosedRange creates a ClosedRangeSet from closed range [lo, hi].
+//
+// If lo > hi, FromClosedRange returns an empty set.
+func FromClosedRange[E Elem](lo, hi E) ClosedRangeSet[E] {
+	var s ClosedRangeSet[E]
+	s.AddRange(lo, hi)
+	return s
+}
+
+// ClosedUniversal returns a ClosedRangeSet that contains every E.
+func ClosedUniversal[E Elem]() ClosedRangeSet[E] {
+	return ClosedRangeSet[E]{Universal[E](), true}
+}
+
+// Closed returns a ClosedRangeSet containing the same elements as set.
+func (set RangeSet[E]) Closed() ClosedRangeSet[E] {
+	return ClosedRangeSet[E]{append(RangeSet[E](nil), set...), false}
+}
+
+// HalfOpen returns a RangeSet containing every element in s except the
+// maximum value of E, and reports whether s contains the maximum value of E.
+//
+// The returned RangeSet shares its backing storage with s.
+func (s ClosedRangeSet[E]) HalfOpen() (RangeSet[E], bool) {
+	return s.set, s.hasMax
+}
+
+// Add adds a single element into s.
+func (s *ClosedRangeSet[E]) Add(v E) {
+	s.AddRange(v, v)
+}
+
+// AddRange adds closed range [lo, hi] into s.
+func (s *ClosedRangeSet[E]) AddRange(lo, hi E) {
+	if lo > hi {
+		return
+	}
+
+	if hi == maxOf[E]() {
+		s.hasMax = true
+		s.set.AddRange(lo, hi)
+
+		return
+	}
+
+	s.set.AddRange(lo, hi+1)
+}
+
+// Delete removes a single element from s.
+func (s *ClosedRangeSet[E]) Delete(v E) {
+	s.DeleteRange(v, v)
+}
+
+// DeleteRange removes closed range [lo, hi] from s.
+func (s *ClosedRangeSet[E]) DeleteRange(lo, hi E) {
+	if lo > hi {
+		return
+	}
+
+	if hi == maxOf[E]() {
+		s.hasMax = false
+		s.set.DeleteRange(lo, hi)
+
+		return
+	}
+
+	s.set.DeleteRange(lo, hi+1)
+}
+
+// Contains reports whether s contains a single element.
+func (s ClosedRangeSet[E]) Contains(v E) bool {
+	return s.ContainsRange(v, v)
+}
+
+// ContainsRange reports whether s contains every element in closed range
+// [lo, hi].
+func (s ClosedRangeSet[E]) ContainsRange(lo, hi E) bool {
+	if lo > hi {
+		return false
+	}
+
+	if hi == maxOf[E]() {
+		return s.hasMax && (lo == hi || s.set.ContainsRange(lo, hi))
+	}
+
+	return s.set.ContainsRange(lo, hi+1)
+}
+
+// IsEmpty reports whether s contains no elements.
+func (s ClosedRangeSet[E]) IsEmpty() bool {
+	return len(s.set) == 0 && !s.hasMax
+}
+
+// Equal reports whether s is identical to other.
+func (s ClosedRangeSet[E]) Equal(other ClosedRangeSet[E]) bool {
+	return s.hasMax == other.hasMax && s.set.Equal(other.set)
+}
+
+// Count returns the number of element in s.
+//
+// If E is a 64-bit type and s contains every E, the number of elements is
+// 2^64, which does not fit in a uint64; Count then saturates and returns
+// math.MaxUint64. Use CountBig or CountOverflow to tell the difference.
+func (s ClosedRangeSet[E]) Count() uint64 {
+	count, overflow := s.CountOverflow()
+	if overflow {
+		return math.MaxUint64
+	}
+
+	return count
+}
+
+// CountOverflow returns the number of element in s, modulo 2^64, and
+// reports whether the actual number overflows a uint64.
+func (s ClosedRangeSet[E]) CountOverflow() (count uint64, overflow bool) {
+	count = s.set.Count()
+
+	if s.hasMax {
+		count++
+		overflow = count == 0
+	}
+
+	return count, overflow
+}
+
+// CountBig returns the number of element in s as a big.Int.
+func (s ClosedRangeSet[E]) CountBig() *big.Int {
+	count, overflow := s.CountOverflow()
+	if overflow {
+		return new(big.Int).Lsh(big.NewInt(1), 64)
+	}
+
+	return new(big.Int).SetUint64(count)
+}
+
+// Complement returns the inverse of s.
+//
+// Unlike RangeSet.Complement, Complement is exact: the complement of an
+// empty set contains every E.
+func (s ClosedRangeSet[E]) Complement() ClosedRangeSet[E] {
+	return ClosedRangeSet[E]{s.set.Complement(), !s.hasMax}
+}
+
+// Union returns the union of s and other.
+func (s ClosedRangeSet[E]) Union(other ClosedRangeSet[E]) ClosedRangeSet[E] {
+	return ClosedRangeSet[E]{s.set.Union(other.set), s.hasMax || other.hasMax}
+}
+
+// Intersection returns the intersection of s and other.
+func (s ClosedRangeSet[E]) Intersection(other ClosedRangeSet[E]) ClosedRangeSet[E] {
+	return ClosedRangeSet[E]{s.set.Intersection(other.set), s.hasMax && other.hasMax}
+}
+
+// Difference returns the subset of s that having all elements in other
+// excluded.
+func (s ClosedRangeSet[E]) Difference(other ClosedRangeSet[E]) ClosedRangeSet[E] {
+	return ClosedRangeSet[E]{s.set.Difference(other.set), s.hasMax && !other.hasMax}
+}
+
+// Intervals returns an iterator over closed ranges [lo, hi] in s, in
+// ascending order.
+func (s ClosedRangeSet[E]) Intervals() iter.Seq2[E, E] {
+	return func(yield func(E, E) bool) {
+		set := s.set
+
+		for i, r := range set {
+			if i == len(set)-1 && s.hasMax && r.High == maxOf[E]() {
+				yield(r.Low, r.High)
+				return
+			}
+
+			if !yield(r.Low, r.High-1) {
+				return
+			}
+		}
+
+		if s.hasMax {
+			yield(maxOf[E](), maxOf[E]())
+		}
+	}
+}
+
+// Elements returns an iterator over every element in s, in ascending order.
+func (s ClosedRangeSet[E]) Elements() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for v := range s.set.Elements() {
+			if !yield(v) {
+				return
+			}
+		}
+
+		if s.hasMax {
+			yield(maxOf[E]())
+		}
+	}
+}
+
+// String returns the text form of s in DashNotation.
+func (s ClosedRangeSet[E]) String() string {
+	var b []byte
+
+	for lo, hi := range s.Intervals() {
+		if len(b) > 0 {
+			b = append(b, ',')
+		}
+
+		b = appendElem(b, lo)
+
+		if lo != hi {
+			b = append(b, '-')
+			b = appendElem(b, hi)
+		}
+	}
+
+	return string(b)
+}
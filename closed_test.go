@@ -0,0 +1,148 @@
+package rangeset_test
+
+import (
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestAdd_max(t *testing.T) {
+	type E uint8
+
+	s := RangeSet[E]{{1, 3}}
+	s.Add(math.MaxUint8)
+
+	if expected := (RangeSet[E]{{1, 3}}); !s.Equal(expected) {
+		t.Fatalf("want %v, but got %v", expected, s)
+	}
+
+	if s.Contains(math.MaxUint8) {
+		t.Fatal("RangeSet contains the maximum value of E")
+	}
+}
+
+func TestClosedRangeSet(t *testing.T) {
+	type E uint8
+
+	var s ClosedRangeSet[E]
+
+	s.Add(math.MaxUint8)
+	s.AddRange(250, 252)
+	s.Add(3)
+
+	assertions := []bool{
+		s.Contains(math.MaxUint8),
+		s.Contains(250),
+		!s.Contains(253),
+		s.ContainsRange(250, 252),
+		!s.ContainsRange(250, math.MaxUint8),
+		s.Count() == 5,
+		s.String() == "3,250-252,255",
+		!s.IsEmpty(),
+	}
+
+	s.AddRange(253, 254)
+
+	assertions = append(assertions,
+		s.ContainsRange(250, math.MaxUint8),
+		s.String() == "3,250-255",
+	)
+
+	s.DeleteRange(254, math.MaxUint8)
+
+	assertions = append(assertions,
+		!s.Contains(math.MaxUint8),
+		s.String() == "3,250-253",
+	)
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
+
+func TestClosedRangeSet_algebra(t *testing.T) {
+	type E int8
+
+	a := FromClosedRange[E](100, math.MaxInt8)
+	b := FromClosedRange[E](math.MinInt8, 110)
+
+	testCases := []struct {
+		Result   ClosedRangeSet[E]
+		Expected string
+	}{
+		{a.Union(b), "-128-127"},
+		{a.Intersection(b), "100-110"},
+		{a.Difference(b), "111-127"},
+		{b.Difference(a), "-128-99"},
+		{a.Complement(), "-128-99"},
+		{ClosedRangeSet[E]{}.Complement(), "-128-127"},
+		{ClosedUniversal[E]().Complement(), ""},
+		{RangeSet[E]{{1, 3}}.Closed(), "1-2"},
+	}
+
+	for i, c := range testCases {
+		if s := c.Result.String(); s != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %q, but got %q", i, c.Expected, s)
+		}
+	}
+
+	if !a.Union(b).Equal(ClosedUniversal[E]()) {
+		t.Fatal("union of a and b is not universal")
+	}
+}
+
+func TestClosedRangeSet_iter(t *testing.T) {
+	type E uint8
+
+	s := FromClosedRange[E](253, math.MaxUint8)
+	s.Add(0)
+
+	if elems := slices.Collect(s.Elements()); !slices.Equal(elems, []E{0, 253, 254, 255}) {
+		t.Fatalf("want [0 253 254 255], but got %v", elems)
+	}
+
+	var his []E
+
+	for _, hi := range s.Intervals() {
+		his = append(his, hi)
+	}
+
+	if !slices.Equal(his, []E{0, 255}) {
+		t.Fatalf("want [0 255], but got %v", his)
+	}
+
+	set, hasMax := s.HalfOpen()
+
+	if !set.Equal(RangeSet[E]{{0, 1}, {253, 255}}) || !hasMax {
+		t.Fatalf("unexpected HalfOpen result: %v, %v", set, hasMax)
+	}
+}
+
+func TestClosedRangeSet_Count(t *testing.T) {
+	full := ClosedUniversal[uint64]()
+
+	count, overflow := full.CountOverflow()
+
+	assertions := []bool{
+		count == 0 && overflow,
+		full.Count() == math.MaxUint64,
+		full.CountBig().Cmp(new(big.Int).Lsh(big.NewInt(1), 64)) == 0,
+		ClosedUniversal[int8]().Count() == 256,
+		ClosedUniversal[int8]().CountBig().Int64() == 256,
+		FromClosedRange[int64](math.MinInt64, -1).Count() == 1<<63,
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package rangeset
+
+import "iter"
+
+// A RangeCounter counts, for every E, how many times it has been covered,
+// i.e. added and not yet deleted.
+//
+// The zero value for a RangeCounter has every E covered zero times.
+type RangeCounter[E Elem] struct {
+	m RangeMap[E, uint] // Only depths greater than zero are stored.
+}
+
+// Add increments the depth of a single element.
+func (c *RangeCounter[E]) Add(v E) {
+	c.AddRange(v, v+1)
+}
+
+// AddRange increments the depth of every element in range [lo, hi).
+func (c *RangeCounter[E]) AddRange(lo, hi E) {
+	c.m.update(lo, hi, func(depth uint, _ bool) (uint, bool) {
+		return depth + 1, true
+	})
+}
+
+// Delete decrements the depth of a single element.
+func (c *RangeCounter[E]) Delete(v E) {
+	c.DeleteRange(v, v+1)
+}
+
+// DeleteRange decrements the depth of every element in range [lo, hi).
+// Elements whose depth is already zero are left unchanged.
+func (c *RangeCounter[E]) DeleteRange(lo, hi E) {
+	c.m.update(lo, hi, func(depth uint, ok bool) (uint, bool) {
+		if !ok || depth == 1 {
+			return 0, false
+		}
+
+		return depth - 1, true
+	})
+}
+
+// Depth returns the number of times v has been covered.
+func (c RangeCounter[E]) Depth(v E) uint {
+	depth, _ := c.m.Get(v)
+	return depth
+}
+
+// MaxDepth returns the largest depth of any element.
+func (c RangeCounter[E]) MaxDepth() uint {
+	var maxDepth uint
+
+	for _, depth := range c.m.All() {
+		maxDepth = max(maxDepth, depth)
+	}
+
+	return maxDepth
+}
+
+// AtLeast returns a RangeSet containing every element whose depth is at
+// least k.
+//
+// If k is zero, AtLeast returns the return value of Universal[E]().
+func (c RangeCounter[E]) AtLeast(k uint) RangeSet[E] {
+	if k == 0 {
+		return Universal[E]()
+	}
+
+	var set RangeSet[E]
+
+	for r, depth := range c.m.All() {
+		if depth < k {
+			continue
+		}
+
+		if n := len(set); n > 0 && set[n-1].High == r.Low {
+			set[n-1].High = r.High
+			continue
+		}
+
+		set = append(set, r)
+	}
+
+	return set
+}
+
+// Segments returns an iterator over maximal Ranges with a constant non-zero
+// depth, in ascending order, along with their depths.
+func (c RangeCounter[E]) Segments() iter.Seq2[Range[E], uint] {
+	return c.m.All()
+}
@@ -0,0 +1,100 @@
+package rangeset_test
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestRangeCounter(t *testing.T) {
+	type E int
+
+	var c RangeCounter[E]
+
+	c.AddRange(1, 10)
+	c.AddRange(5, 15)
+	c.AddRange(7, 8)
+	c.Add(20)
+
+	testCases := []struct {
+		Result, Expected uint
+	}{
+		{c.Depth(0), 0},
+		{c.Depth(1), 1},
+		{c.Depth(5), 2},
+		{c.Depth(7), 3},
+		{c.Depth(8), 2},
+		{c.Depth(10), 1},
+		{c.Depth(15), 0},
+		{c.Depth(20), 1},
+		{c.MaxDepth(), 3},
+	}
+
+	for i, tc := range testCases {
+		if tc.Result != tc.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, tc.Expected, tc.Result)
+		}
+	}
+
+	sets := []struct {
+		Result, Expected RangeSet[E]
+	}{
+		{c.AtLeast(1), RangeSet[E]{{1, 15}, {20, 21}}},
+		{c.AtLeast(2), RangeSet[E]{{5, 10}}},
+		{c.AtLeast(3), RangeSet[E]{{7, 8}}},
+		{c.AtLeast(4), RangeSet[E]{}},
+		{c.AtLeast(0), Universal[E]()},
+	}
+
+	for i, tc := range sets {
+		if !tc.Result.Equal(tc.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, tc.Expected, tc.Result)
+		}
+	}
+
+	var segs []uint
+
+	for _, depth := range c.Segments() {
+		segs = append(segs, depth)
+	}
+
+	if want := []uint{1, 2, 3, 2, 1, 1}; !slices.Equal(segs, want) {
+		t.Fatalf("want depths %v, but got %v", want, segs)
+	}
+}
+
+func TestRangeCounter_delete(t *testing.T) {
+	type E uint
+
+	var c RangeCounter[E]
+
+	c.AddRange(1, 10)
+	c.AddRange(5, 15)
+	c.DeleteRange(0, 20)
+	c.Delete(7)
+
+	assertions := []bool{
+		c.Depth(3) == 0,
+		c.Depth(7) == 0,
+		c.Depth(8) == 1,
+		c.MaxDepth() == 1,
+		c.AtLeast(1).Equal(RangeSet[E]{{5, 7}, {8, 10}}),
+	}
+
+	c.DeleteRange(0, 20)
+
+	assertions = append(assertions,
+		c.MaxDepth() == 0,
+		c.AtLeast(1).Equal(RangeSet[E]{}),
+	)
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/b97tsk/rangeset
 
-go 1.18
+go 1.23
 
 require golang.org/x/exp v0.0.0-20220323204016-c86f0da35e87
 
@@ -0,0 +1,41 @@
+package rangeset
+
+import (
+	"slices"
+	"unsafe"
+)
+
+// inPlace sets *set to op(dst, *set, other), where dst shares the backing
+// storage of set.
+//
+// To make it safe, set is first moved to the end of a buffer that is
+// len(set)+len(other) long, and op writes its result from the start of the
+// buffer. Since op never produces more Ranges than it has read, the
+// writing never catches up with the reading.
+func (set *RangeSet[E]) inPlace(other RangeSet[E], op func(dst, s1, s2 RangeSet[E]) RangeSet[E]) {
+	s := *set
+
+	if sharesStorage(s, other) {
+		other = slices.Clone(other)
+	}
+
+	n, m := len(s), len(other)
+
+	s = slices.Grow(s, m)[:n+m]
+	copy(s[m:], s[:n])
+
+	*set = op(s[:0], s[m:], other)
+}
+
+// sharesStorage reports whether s1 and s2 share any backing storage.
+func sharesStorage[E Elem](s1, s2 RangeSet[E]) bool {
+	if cap(s1) == 0 || cap(s2) == 0 {
+		return false
+	}
+
+	size := unsafe.Sizeof(Range[E]{})
+	p1 := uintptr(unsafe.Pointer(unsafe.SliceData(s1)))
+	p2 := uintptr(unsafe.Pointer(unsafe.SliceData(s2)))
+
+	return p1 < p2+uintptr(cap(s2))*size && p2 < p1+uintptr(cap(s1))*size
+}
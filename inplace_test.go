@@ -0,0 +1,179 @@
+package rangeset_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestInPlace(t *testing.T) {
+	type E int
+
+	testCases := []struct {
+		Result, Expected RangeSet[E]
+	}{
+		{
+			func() RangeSet[E] {
+				s := RangeSet[E]{{1, 5}, {9, 13}}
+				s.UnionWith(RangeSet[E]{{3, 10}, {20, 21}})
+				return s
+			}(),
+			RangeSet[E]{{1, 13}, {20, 21}},
+		},
+		{
+			func() RangeSet[E] {
+				s := RangeSet[E]{{1, 5}, {9, 13}}
+				s.IntersectWith(RangeSet[E]{{3, 10}, {20, 21}})
+				return s
+			}(),
+			RangeSet[E]{{3, 5}, {9, 10}},
+		},
+		{
+			func() RangeSet[E] {
+				s := RangeSet[E]{{1, 5}, {9, 13}}
+				s.DifferenceWith(RangeSet[E]{{2, 3}, {4, 10}})
+				return s
+			}(),
+			RangeSet[E]{{1, 2}, {3, 4}, {10, 13}},
+		},
+		{
+			func() RangeSet[E] {
+				s := RangeSet[E]{{1, 5}, {9, 13}}
+				s.SymmetricDifferenceWith(RangeSet[E]{{3, 10}})
+				return s
+			}(),
+			RangeSet[E]{{1, 3}, {5, 9}, {10, 13}},
+		},
+		{
+			func() RangeSet[E] {
+				s := RangeSet[E]{{1, 5}, {9, 13}}
+				s.UnionWith(s)
+				return s
+			}(),
+			RangeSet[E]{{1, 5}, {9, 13}},
+		},
+		{
+			func() RangeSet[E] {
+				s := RangeSet[E]{{1, 5}, {9, 13}}
+				s.IntersectWith(s[1:])
+				return s
+			}(),
+			RangeSet[E]{{9, 13}},
+		},
+		{
+			func() RangeSet[E] {
+				s := RangeSet[E]{{1, 5}, {9, 13}}
+				s.DifferenceWith(s)
+				return s
+			}(),
+			RangeSet[E]{},
+		},
+		{
+			func() RangeSet[E] {
+				s := RangeSet[E]{{1, 5}, {9, 13}}
+				s.SymmetricDifferenceWith(s)
+				return s
+			}(),
+			RangeSet[E]{},
+		},
+	}
+
+	for i, c := range testCases {
+		if !c.Result.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
+
+func TestInPlace_random(t *testing.T) {
+	type E int
+
+	rng := rand.New(rand.NewSource(1))
+
+	random := func() RangeSet[E] {
+		var s RangeSet[E]
+
+		for i := rng.Intn(20); i > 0; i-- {
+			lo := E(rng.Intn(300))
+			s.AddRange(lo, lo+E(rng.Intn(20)))
+		}
+
+		return s
+	}
+
+	for n := 0; n < 500; n++ {
+		a, b := random(), random()
+
+		ops := []struct {
+			Name     string
+			InPlace  func(*RangeSet[E], RangeSet[E])
+			Expected RangeSet[E]
+		}{
+			{"UnionWith", (*RangeSet[E]).UnionWith, Union(a, b)},
+			{"IntersectWith", (*RangeSet[E]).IntersectWith, Intersection(a, b)},
+			{"DifferenceWith", (*RangeSet[E]).DifferenceWith, a.Intersection(b.Complement())},
+			{"SymmetricDifferenceWith", (*RangeSet[E]).SymmetricDifferenceWith, SymmetricDifference(a, b)},
+		}
+
+		for _, op := range ops {
+			// Make sure the capacity is just enough, so that every in-place
+			// operation reuses the backing storage.
+			s := make(RangeSet[E], len(a), len(a)+len(b))
+			copy(s, a)
+			op.InPlace(&s, b)
+
+			if !s.Equal(op.Expected) {
+				t.Fatalf("%v(%v, %v): want %v, but got %v", op.Name, a, b, op.Expected, s)
+			}
+		}
+	}
+}
+
+func TestInPlace_allocs(t *testing.T) {
+	type E int
+
+	a := RangeSet[E]{{1, 5}, {9, 13}, {20, 30}}
+	b := RangeSet[E]{{3, 10}, {12, 22}, {25, 26}, {40, 50}}
+
+	buf := make(RangeSet[E], 0, len(a)+len(b))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		s := append(buf[:0], a...)
+		s.UnionWith(b)
+		s = append(buf[:0], a...)
+		s.IntersectWith(b)
+		s = append(buf[:0], a...)
+		s.DifferenceWith(b)
+	})
+
+	if allocs != 0 {
+		t.Fatalf("want no allocations, but got %v", allocs)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	type E int
+
+	a := RangeSet[E]{{10, 15}, {19, 25}}
+	b := RangeSet[E]{{12, 20}}
+	dst := RangeSet[E]{{1, 2}}
+
+	testCases := []struct {
+		Result, Expected RangeSet[E]
+	}{
+		{AppendUnion(dst, a, b), RangeSet[E]{{1, 2}, {10, 25}}},
+		{AppendIntersection(dst, a, b), RangeSet[E]{{1, 2}, {12, 15}, {19, 20}}},
+		{AppendDifference(dst, a, b), RangeSet[E]{{1, 2}, {10, 12}, {20, 25}}},
+		{AppendSymmetricDifference(dst, a, b), RangeSet[E]{{1, 2}, {10, 12}, {15, 19}, {20, 25}}},
+		{dst, RangeSet[E]{{1, 2}}},
+	}
+
+	for i, c := range testCases {
+		if !c.Result.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
@@ -4,18 +4,93 @@ import "sort"
 
 // Intersection returns the intersection of set and other.
 func (set RangeSet[E]) Intersection(other RangeSet[E]) RangeSet[E] {
-	return intersectionBuffer(set, other, nil)
+	return appendIntersection(nil, set, other)
 }
 
 // Intersection returns the intersection of zero or more sets.
+//
+// Intersection sweeps through all sets at once, skipping Ranges with binary
+// searches, and stops as soon as any set runs out of Ranges.
 func Intersection[E Elem](sets ...RangeSet[E]) RangeSet[E] {
-	return combine(intersectionBuffer[E], sets...)
+	switch len(sets) {
+	case 0:
+		return nil
+	case 1:
+		// Always return a distinct set (unless it's nil).
+		return append(RangeSet[E](nil), sets[0]...)
+	case 2:
+		return appendIntersection(nil, sets[0], sets[1])
+	}
+
+	for _, s := range sets {
+		if len(s) == 0 {
+			return nil
+		}
+	}
+
+	var res RangeSet[E]
+
+	cur := make([]RangeSet[E], len(sets)) // Remaining Ranges of each set.
+	copy(cur, sets)
+
+	lo := cur[0][0].Low
+
+	for {
+		// Find the smallest lo (not less than the current one) that every
+		// set contains. Stop once all k sets in a row agree on it.
+		for i, agree := 0, 0; agree < len(cur); i = (i + 1) % len(cur) {
+			s := cur[i]
+			s = s[sort.Search(len(s), func(i int) bool { return s[i].High > lo }):]
+			cur[i] = s
+
+			if len(s) == 0 {
+				return res
+			}
+
+			if s[0].Low > lo {
+				lo = s[0].Low
+				agree = 1
+			} else {
+				agree++
+			}
+		}
+
+		hi := cur[0][0].High
+
+		for _, s := range cur[1:] {
+			if s[0].High < hi {
+				hi = s[0].High
+			}
+		}
+
+		res = append(res, Range[E]{lo, hi})
+		lo = hi
+	}
+}
+
+// AppendIntersection appends the intersection of s1 and s2 to dst and
+// returns the extended buffer.
+//
+// dst must not share backing storage with s1 or s2, and the result is
+// a valid RangeSet only if dst is empty or ends before s1 and s2 begin.
+func AppendIntersection[E Elem](dst, s1, s2 RangeSet[E]) RangeSet[E] {
+	return appendIntersection(dst, s1, s2)
+}
+
+// IntersectWith sets set to the intersection of set and other.
+//
+// IntersectWith reuses the backing storage of set if its capacity is at
+// least len(set)+len(other).
+func (set *RangeSet[E]) IntersectWith(other RangeSet[E]) {
+	set.inPlace(other, appendIntersection[E])
 }
 
-// intersectionBuffer returns the intersection of s1 and s2, using buf as
-// its initial backing storage.
-func intersectionBuffer[E Elem](s1, s2, buf RangeSet[E]) RangeSet[E] {
-	res := buf[:0]
+// appendIntersection appends the intersection of s1 and s2 to dst.
+//
+// appendIntersection never writes past the Ranges it has already read,
+// which makes it usable by inPlace.
+func appendIntersection[E Elem](dst, s1, s2 RangeSet[E]) RangeSet[E] {
+	res := dst
 
 	for {
 		if len(s1) < len(s2) {
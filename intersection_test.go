@@ -1,6 +1,7 @@
 package rangeset_test
 
 import (
+	"math/rand"
 	"testing"
 
 	. "github.com/b97tsk/rangeset"
@@ -35,6 +36,23 @@ func TestIntersection(t *testing.T) {
 			),
 			RangeSet[E]{{9, 11}, {13, 15}},
 		},
+		{
+			Intersection(
+				RangeSet[E]{{1, 10}},
+				RangeSet[E]{{2, 9}},
+				RangeSet[E]{},
+			),
+			RangeSet[E]{},
+		},
+		{
+			Intersection(
+				RangeSet[E]{{1, 10}, {20, 30}},
+				RangeSet[E]{{2, 25}},
+				RangeSet[E]{{0, 4}, {5, 6}, {8, 22}},
+				RangeSet[E]{{3, 40}},
+			),
+			RangeSet[E]{{3, 4}, {5, 6}, {8, 10}, {20, 22}},
+		},
 		{Intersection[E](), RangeSet[E]{}},
 		{Intersection(RangeSet[E]{}), RangeSet[E]{}},
 		{
@@ -66,3 +84,30 @@ func TestIntersection(t *testing.T) {
 		}
 	}
 }
+
+func TestIntersection_random(t *testing.T) {
+	type E int
+
+	rng := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 200; n++ {
+		sets := make([]RangeSet[E], 3+rng.Intn(5))
+
+		for i := range sets {
+			for j := 0; j < 20; j++ {
+				lo := E(rng.Intn(200))
+				sets[i].AddRange(lo, lo+E(rng.Intn(50)))
+			}
+		}
+
+		expected := sets[0]
+
+		for _, s := range sets[1:] {
+			expected = expected.Intersection(s)
+		}
+
+		if result := Intersection(sets...); !result.Equal(expected) {
+			t.Fatalf("Intersection(%v): want %v, but got %v", sets, expected, result)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+package rangeset
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+// An IPRange is a closed interval of IP addresses, both of the same family.
+type IPRange struct {
+	From netip.Addr // inclusive
+	To   netip.Addr // inclusive
+}
+
+// An IPSet is a slice of discrete IPRanges sorted in ascending order, with
+// IPv4 addresses before IPv6 ones.
+// The zero value for an IPSet, i.e. a nil IPSet, is an empty set.
+//
+// Unlike RangeSet, IPRanges are closed, so that the last address of each
+// family (e.g. 255.255.255.255) can be held too. IPv4 and IPv4-mapped IPv6
+// addresses are distinct, and zones are ignored.
+type IPSet []IPRange
+
+// Add adds a single address into set.
+func (set *IPSet) Add(addr netip.Addr) {
+	set.AddRange(addr, addr)
+}
+
+// AddPrefix adds every address in p into set.
+func (set *IPSet) AddPrefix(p netip.Prefix) {
+	if !p.IsValid() {
+		return
+	}
+
+	p = p.Masked()
+	from := p.Addr()
+	to := uint128From(from).or(uint128Mask(from.BitLen() - p.Bits())).addr(from.Is4())
+
+	set.AddRange(from, to)
+}
+
+// AddRange adds every address from from to to (inclusive) into set.
+//
+// If from and to are of different families, or from is greater than to,
+// AddRange does nothing.
+func (set *IPSet) AddRange(from, to netip.Addr) {
+	from, to = from.WithZone(""), to.WithZone("")
+
+	if !from.IsValid() || !to.IsValid() || from.Is4() != to.Is4() || to.Less(from) {
+		return
+	}
+
+	s := *set
+
+	// IPRanges i through j-1 overlap or adjoin [from, to], and are merged
+	// into one. Next returns the zero Addr after the last address of each
+	// family, so IPRanges of different families never adjoin.
+
+	i := sort.Search(len(s), func(i int) bool { return s[i].To.Compare(from) >= 0 })
+	if i > 0 && s[i-1].To.Next() == from {
+		i--
+	}
+
+	j := sort.Search(len(s), func(i int) bool { return s[i].From.Compare(to) > 0 })
+	if j < len(s) && to.Next() == s[j].From {
+		j++
+	}
+
+	if i == j {
+		s = append(s, IPRange{})
+		copy(s[i+1:], s[i:])
+		s[i] = IPRange{from, to}
+		*set = s
+
+		return
+	}
+
+	if s[i].From.Less(from) {
+		from = s[i].From
+	}
+
+	if to.Less(s[j-1].To) {
+		to = s[j-1].To
+	}
+
+	s[i] = IPRange{from, to}
+	s = append(s[:i+1], s[j:]...)
+	*set = s
+}
+
+// Contains reports whether set contains addr.
+func (set IPSet) Contains(addr netip.Addr) bool {
+	addr = addr.WithZone("")
+
+	i := sort.Search(len(set), func(i int) bool { return set[i].To.Compare(addr) >= 0 })
+
+	return i < len(set) && set[i].From.Compare(addr) <= 0
+}
+
+// Equal reports whether set is identical to other.
+func (set IPSet) Equal(other IPSet) bool {
+	if len(set) != len(other) {
+		return false
+	}
+
+	for i, r := range set {
+		if r != other[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Prefixes returns the fewest CIDR prefixes that cover exactly set, in
+// ascending order.
+func (set IPSet) Prefixes() []netip.Prefix {
+	var prefixes []netip.Prefix
+
+	for _, r := range set {
+		prefixes = appendPrefixes(prefixes, r)
+	}
+
+	return prefixes
+}
+
+// appendPrefixes appends to prefixes the fewest CIDR prefixes that cover
+// exactly r.
+//
+// Starting from the first address, it takes the largest prefix that is
+// aligned at that address and does not go past the last address, until the
+// whole IPRange is covered.
+func appendPrefixes(prefixes []netip.Prefix, r IPRange) []netip.Prefix {
+	is4, bitLen := r.From.Is4(), r.From.BitLen()
+	lo, hi := uint128From(r.From), uint128From(r.To)
+
+	for {
+		k := min(lo.trailingZeros(), bitLen)
+		for lo.or(uint128Mask(k)).cmp(hi) > 0 {
+			k--
+		}
+
+		prefixes = append(prefixes, netip.PrefixFrom(lo.addr(is4), bitLen-k))
+
+		last := lo.or(uint128Mask(k))
+		if last == hi {
+			return prefixes
+		}
+
+		lo = last.addOne()
+	}
+}
+
+// String returns the text form of set, e.g. "10.0.0.0/8,192.168.1.5-192.168.1.20".
+//
+// Each IPRange is written as a single address, as a CIDR prefix if it is
+// exactly one, or as an inclusive "from-to" pair otherwise.
+func (set IPSet) String() string {
+	var b []byte
+
+	for i, r := range set {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		switch p := appendPrefixes(nil, r); {
+		case r.From == r.To:
+			b = r.From.AppendTo(b)
+		case len(p) == 1:
+			b = p[0].AppendTo(b)
+		default:
+			b = r.From.AppendTo(b)
+			b = append(b, '-')
+			b = r.To.AppendTo(b)
+		}
+	}
+
+	return string(b)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (set IPSet) MarshalText() ([]byte, error) {
+	return []byte(set.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// See ParseIPSet for accepted text forms.
+func (set *IPSet) UnmarshalText(text []byte) error {
+	s, err := ParseIPSet(string(text))
+	if err != nil {
+		return err
+	}
+
+	*set = s
+
+	return nil
+}
+
+// ParseIPSet parses a comma-separated list of IP addresses into an IPSet.
+//
+// Each item can be written in any of the following forms:
+//
+//	192.168.1.5                  a single address
+//	10.0.0.0/8                   a CIDR prefix
+//	192.168.1.5-192.168.1.20     an inclusive range
+//
+// IPv6 addresses are accepted in each form too. Items may appear in any
+// order and may overlap; the result is sorted and merged. Whitespace around
+// items and addresses is ignored. An empty or all-whitespace string yields
+// an empty set; otherwise, every item must be non-empty, including the last
+// one.
+func ParseIPSet(s string) (IPSet, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var res IPSet
+
+	for more := true; more; {
+		var item string
+
+		item, s, more = strings.Cut(s, ",")
+
+		r, err := parseIPRange(strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+
+		res.AddRange(r.From, r.To)
+	}
+
+	return res, nil
+}
+
+var errMixedFamilies = errors.New("addresses of different families")
+
+func parseIPRange(item string) (r IPRange, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("rangeset: parsing %q: %w", item, err)
+		}
+	}()
+
+	if item == "" {
+		return r, errEmptyItem
+	}
+
+	if strings.Contains(item, "/") {
+		p, err := netip.ParsePrefix(item)
+		if err != nil {
+			return r, err
+		}
+
+		var s IPSet
+
+		s.AddPrefix(p)
+
+		return s[0], nil
+	}
+
+	from, to, ok := strings.Cut(item, "-")
+
+	if r.From, err = netip.ParseAddr(strings.TrimSpace(from)); err != nil {
+		return
+	}
+
+	if !ok {
+		r.To = r.From
+		return
+	}
+
+	if r.To, err = netip.ParseAddr(strings.TrimSpace(to)); err != nil {
+		return
+	}
+
+	if r.From.Is4() != r.To.Is4() {
+		return r, errMixedFamilies
+	}
+
+	if r.To.Less(r.From) {
+		return r, errReversed
+	}
+
+	return r, nil
+}
+
+// uint128 is a 128-bit unsigned integer, used for address arithmetic.
+type uint128 struct {
+	hi, lo uint64
+}
+
+// uint128From returns addr as a number. An IPv4 address takes the lowest
+// 32 bits.
+func uint128From(addr netip.Addr) uint128 {
+	if addr.Is4() {
+		a := addr.As4()
+		return uint128{0, uint64(binary.BigEndian.Uint32(a[:]))}
+	}
+
+	a := addr.As16()
+
+	return uint128{binary.BigEndian.Uint64(a[:8]), binary.BigEndian.Uint64(a[8:])}
+}
+
+// uint128Mask returns a number with the lowest k bits set.
+func uint128Mask(k int) uint128 {
+	switch {
+	case k >= 128:
+		return uint128{^uint64(0), ^uint64(0)}
+	case k >= 64:
+		return uint128{1<<(k-64) - 1, ^uint64(0)}
+	default:
+		return uint128{0, 1<<k - 1}
+	}
+}
+
+// addr is the inverse of uint128From.
+func (u uint128) addr(is4 bool) netip.Addr {
+	if is4 {
+		var a [4]byte
+		binary.BigEndian.PutUint32(a[:], uint32(u.lo))
+		return netip.AddrFrom4(a)
+	}
+
+	var a [16]byte
+
+	binary.BigEndian.PutUint64(a[:8], u.hi)
+	binary.BigEndian.PutUint64(a[8:], u.lo)
+
+	return netip.AddrFrom16(a)
+}
+
+func (u uint128) or(v uint128) uint128 {
+	return uint128{u.hi | v.hi, u.lo | v.lo}
+}
+
+func (u uint128) addOne() uint128 {
+	lo, carry := bits.Add64(u.lo, 1, 0)
+	return uint128{u.hi + carry, lo}
+}
+
+func (u uint128) cmp(v uint128) int {
+	switch {
+	case u.hi < v.hi || u.hi == v.hi && u.lo < v.lo:
+		return -1
+	case u == v:
+		return 0
+	default:
+		return 1
+	}
+}
+
+func (u uint128) trailingZeros() int {
+	if u.lo != 0 {
+		return bits.TrailingZeros64(u.lo)
+	}
+
+	return 64 + bits.TrailingZeros64(u.hi)
+}
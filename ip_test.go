@@ -0,0 +1,209 @@
+package rangeset_test
+
+import (
+	"math/rand"
+	"net/netip"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestIPSet(t *testing.T) {
+	addr := netip.MustParseAddr
+	prefix := netip.MustParsePrefix
+
+	var s IPSet
+
+	s.AddPrefix(prefix("10.0.0.0/8"))
+	s.AddRange(addr("192.168.1.5"), addr("192.168.1.20"))
+	s.AddPrefix(prefix("2001:db8::1/32"))
+	s.Add(addr("11.0.0.0"))
+	s.Add(addr("9.255.255.255"))
+	s.Add(addr("255.255.255.255"))
+	s.Add(addr("::"))
+	s.AddRange(addr("192.168.1.21"), addr("::1")) // Different families.
+	s.AddRange(addr("192.168.1.21"), addr("192.168.1.20"))
+
+	expected := IPSet{
+		{addr("9.255.255.255"), addr("11.0.0.0")},
+		{addr("192.168.1.5"), addr("192.168.1.20")},
+		{addr("255.255.255.255"), addr("255.255.255.255")},
+		{addr("::"), addr("::")},
+		{addr("2001:db8::"), addr("2001:db8:ffff:ffff:ffff:ffff:ffff:ffff")},
+	}
+
+	if !s.Equal(expected) {
+		t.Fatalf("want %v, but got %v", expected, s)
+	}
+
+	assertions := []bool{
+		s.Contains(addr("10.1.2.3")),
+		s.Contains(addr("192.168.1.5")),
+		s.Contains(addr("192.168.1.20")),
+		!s.Contains(addr("192.168.1.21")),
+		!s.Contains(addr("192.168.1.4")),
+		s.Contains(addr("255.255.255.255")),
+		s.Contains(addr("::")),
+		!s.Contains(addr("::1")),
+		!s.Contains(addr("::ffff:10.0.0.1")),
+		s.Contains(addr("2001:db8::1%eth0")),
+		!s.Contains(netip.Addr{}),
+		!IPSet{}.Contains(addr("10.0.0.1")),
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
+
+func TestIPSetPrefixes(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected string
+	}{
+		{"", ""},
+		{"10.0.0.0/8", "10.0.0.0/8"},
+		{"192.168.1.5-192.168.1.20", "192.168.1.5/32,192.168.1.6/31,192.168.1.8/29,192.168.1.16/30,192.168.1.20/32"},
+		{"0.0.0.0-255.255.255.255", "0.0.0.0/0"},
+		{"0.0.0.1-255.255.255.255", "0.0.0.1/32,0.0.0.2/31,0.0.0.4/30,0.0.0.8/29,0.0.0.16/28," +
+			"0.0.0.32/27,0.0.0.64/26,0.0.0.128/25,0.0.1.0/24,0.0.2.0/23,0.0.4.0/22,0.0.8.0/21," +
+			"0.0.16.0/20,0.0.32.0/19,0.0.64.0/18,0.0.128.0/17,0.1.0.0/16,0.2.0.0/15,0.4.0.0/14," +
+			"0.8.0.0/13,0.16.0.0/12,0.32.0.0/11,0.64.0.0/10,0.128.0.0/9,1.0.0.0/8,2.0.0.0/7," +
+			"4.0.0.0/6,8.0.0.0/5,16.0.0.0/4,32.0.0.0/3,64.0.0.0/2,128.0.0.0/1"},
+		{"::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "::/0"},
+		{"1::ffff:ffff:ffff:ffff-1::1:0:0:0:0", "1::ffff:ffff:ffff:ffff/128,1:0:0:1::/128"},
+		{"10.0.0.0/8, ::/0", "10.0.0.0/8,::/0"},
+	}
+
+	for i, c := range testCases {
+		if result := prefixesOf(c.Input); result != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %q, but got %q", i, c.Expected, result)
+		}
+	}
+
+	// The last address of ::/1 is left out, so it takes 127 prefixes to cover.
+	if s, _ := ParseIPSet("::-7fff:ffff:ffff:ffff:ffff:ffff:ffff:fffe"); len(s.Prefixes()) != 127 {
+		t.Fatalf("want 127 prefixes, but got %v", len(s.Prefixes()))
+	}
+}
+
+func prefixesOf(input string) string {
+	s, err := ParseIPSet(input)
+	if err != nil {
+		return err.Error()
+	}
+
+	var b []byte
+
+	for i, p := range s.Prefixes() {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		b = p.AppendTo(b)
+	}
+
+	return string(b)
+}
+
+func TestIPSetPrefixes_random(t *testing.T) {
+	rand := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		var s IPSet
+
+		for j := rand.Intn(8); j > 0; j-- {
+			var a [4]byte
+
+			rand.Read(a[:])
+
+			from := netip.AddrFrom4(a)
+			to := from
+
+			for k := rand.Intn(1000); k > 0 && to.Next().IsValid(); k-- {
+				to = to.Next()
+			}
+
+			s.AddRange(from, to)
+		}
+
+		var r IPSet
+
+		prefixes := s.Prefixes()
+
+		for j, p := range prefixes {
+			if j > 0 && prefixes[j-1].Addr().Compare(p.Addr()) >= 0 {
+				t.Fatalf("Case %v: prefixes are not in ascending order: %v", i, prefixes)
+			}
+
+			if p.Bits() > 0 {
+				// No two consecutive prefixes should be mergeable.
+				if j > 0 && prefixes[j-1].Bits() == p.Bits() {
+					if parent, _ := p.Addr().Prefix(p.Bits() - 1); parent.Contains(prefixes[j-1].Addr()) {
+						t.Fatalf("Case %v: %v and %v can be merged", i, prefixes[j-1], p)
+					}
+				}
+			}
+
+			r.AddPrefix(p)
+		}
+
+		if !r.Equal(s) {
+			t.Fatalf("Case %v: want %v, but got %v", i, s, r)
+		}
+	}
+}
+
+func TestParseIPSet(t *testing.T) {
+	s, err := ParseIPSet(" 192.168.1.5 - 192.168.1.20 , 10.1.2.3/8,192.168.1.21,2001:db8::/32")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "10.0.0.0/8,192.168.1.5-192.168.1.21,2001:db8::/32"
+
+	if s.String() != expected {
+		t.Fatalf("want %q, but got %q", expected, s.String())
+	}
+
+	text, _ := s.MarshalText()
+
+	var r IPSet
+
+	if err := r.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+
+	if !r.Equal(s) {
+		t.Fatalf("want %v, but got %v", s, r)
+	}
+
+	if s, err := ParseIPSet(" "); err != nil || len(s) != 0 {
+		t.Fatalf("want an empty set, but got %v, %v", s, err)
+	}
+}
+
+func TestParseIPSet_error(t *testing.T) {
+	inputs := []string{
+		"10.0.0.1,,10.0.0.2",
+		"10.0.0.1,",
+		",10.0.0.1",
+		"10.0.0.256",
+		"10.0.0.0/33",
+		"10.0.0.9-10.0.0.1",
+		"10.0.0.1-::1",
+		"10.0.0.1-",
+		"fe80::1%eth0/64",
+	}
+
+	for i, input := range inputs {
+		if _, err := ParseIPSet(input); err == nil {
+			t.Fail()
+			t.Logf("Case %v: want an error for %q, but got nil", i, input)
+		}
+	}
+}
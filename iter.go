@@ -0,0 +1,85 @@
+package rangeset
+
+import "iter"
+
+// Elements returns an iterator over every element in set, in ascending
+// order.
+func (set RangeSet[E]) Elements() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for _, r := range set {
+			for v := r.Low; v < r.High; v++ {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ElementsBackward returns an iterator over every element in set, in
+// descending order.
+func (set RangeSet[E]) ElementsBackward() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for i := len(set) - 1; i >= 0; i-- {
+			r := set[i]
+
+			for v := r.High; v > r.Low; {
+				v--
+
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Ranges returns an iterator over Ranges in set, in ascending order.
+func (set RangeSet[E]) Ranges() iter.Seq[Range[E]] {
+	return func(yield func(Range[E]) bool) {
+		for _, r := range set {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// RangesBackward returns an iterator over Ranges in set, in descending
+// order.
+func (set RangeSet[E]) RangesBackward() iter.Seq[Range[E]] {
+	return func(yield func(Range[E]) bool) {
+		for i := len(set) - 1; i >= 0; i-- {
+			if !yield(set[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Gaps returns an iterator over Ranges between adjacent Ranges in set,
+// in ascending order.
+//
+// Gaps yields the complement of set within set.Extent(), without
+// allocating.
+func (set RangeSet[E]) Gaps() iter.Seq[Range[E]] {
+	return func(yield func(Range[E]) bool) {
+		for i := 1; i < len(set); i++ {
+			if !yield(Range[E]{set[i-1].High, set[i].Low}) {
+				return
+			}
+		}
+	}
+}
+
+// GapsBackward returns an iterator over Ranges between adjacent Ranges in
+// set, in descending order.
+func (set RangeSet[E]) GapsBackward() iter.Seq[Range[E]] {
+	return func(yield func(Range[E]) bool) {
+		for i := len(set) - 1; i > 0; i-- {
+			if !yield(Range[E]{set[i-1].High, set[i].Low}) {
+				return
+			}
+		}
+	}
+}
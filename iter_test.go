@@ -0,0 +1,88 @@
+package rangeset_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestElements(t *testing.T) {
+	type E int
+
+	s := RangeSet[E]{{1, 4}, {6, 8}}
+
+	testCases := []struct {
+		Result, Expected []E
+	}{
+		{slices.Collect(s.Elements()), []E{1, 2, 3, 6, 7}},
+		{slices.Collect(s.ElementsBackward()), []E{7, 6, 3, 2, 1}},
+		{slices.Collect(RangeSet[E]{}.Elements()), nil},
+		{
+			func() (res []E) {
+				for v := range s.Elements() {
+					if v > 2 {
+						break
+					}
+
+					res = append(res, v)
+				}
+
+				return
+			}(),
+			[]E{1, 2},
+		},
+	}
+
+	for i, c := range testCases {
+		if !slices.Equal(c.Result, c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
+
+func TestElements_boundary(t *testing.T) {
+	type E uint8
+
+	s := RangeSet[E]{{0, 2}, {math.MaxUint8 - 1, math.MaxUint8}}
+
+	testCases := []struct {
+		Result, Expected []E
+	}{
+		{slices.Collect(s.Elements()), []E{0, 1, math.MaxUint8 - 1}},
+		{slices.Collect(s.ElementsBackward()), []E{math.MaxUint8 - 1, 1, 0}},
+	}
+
+	for i, c := range testCases {
+		if !slices.Equal(c.Result, c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
+
+func TestRanges(t *testing.T) {
+	type E int
+
+	s := RangeSet[E]{{1, 4}, {6, 8}, {10, 11}}
+
+	testCases := []struct {
+		Result, Expected []Range[E]
+	}{
+		{slices.Collect(s.Ranges()), []Range[E]{{1, 4}, {6, 8}, {10, 11}}},
+		{slices.Collect(s.RangesBackward()), []Range[E]{{10, 11}, {6, 8}, {1, 4}}},
+		{slices.Collect(s.Gaps()), []Range[E]{{4, 6}, {8, 10}}},
+		{slices.Collect(s.GapsBackward()), []Range[E]{{8, 10}, {4, 6}}},
+		{slices.Collect(RangeSet[E]{{1, 4}}.Gaps()), nil},
+		{slices.Collect(RangeSet[E]{}.GapsBackward()), nil},
+	}
+
+	for i, c := range testCases {
+		if !slices.Equal(c.Result, c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package rangeset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var errEmptyJSON = errors.New("rangeset: empty JSON input")
+
+// MarshalJSON implements the json.Marshaler interface.
+// A Range is encoded as a two-element array [lo,hi].
+func (r Range[E]) MarshalJSON() ([]byte, error) {
+	return appendJSONPair(nil, r), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts either a two-element array [lo,hi] or an object
+// {"low":lo,"high":hi}.
+func (r *Range[E]) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 {
+		return errEmptyJSON
+	}
+
+	if string(data) == "null" {
+		return nil
+	}
+
+	var lo, hi E
+
+	switch data[0] {
+	case '[':
+		var a []E
+
+		if err := json.Unmarshal(data, &a); err != nil {
+			return err
+		}
+
+		if len(a) != 2 {
+			return errors.New("rangeset: JSON array for Range must have exactly two elements")
+		}
+
+		lo, hi = a[0], a[1]
+	case '{':
+		var o struct {
+			Low  *E `json:"low"`
+			High *E `json:"high"`
+		}
+
+		if err := json.Unmarshal(data, &o); err != nil {
+			return err
+		}
+
+		if o.Low == nil || o.High == nil {
+			return errors.New(`rangeset: JSON object for Range must have both "low" and "high"`)
+		}
+
+		lo, hi = *o.Low, *o.High
+	default:
+		return errors.New("rangeset: JSON value for Range must be an array or an object")
+	}
+
+	if lo > hi {
+		return fmt.Errorf("rangeset: decoding Range [%v,%v): %w", lo, hi, errReversed)
+	}
+
+	*r = Range[E]{lo, hi}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// A RangeSet is encoded as an array of pairs, e.g. [[1,6],[7,8]].
+//
+// To encode a RangeSet in other shapes, convert it to JSONObjects or
+// JSONString.
+func (set RangeSet[E]) MarshalJSON() ([]byte, error) {
+	b := append(make([]byte, 0, 16*len(set)+2), '[')
+
+	for i, r := range set {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		b = appendJSONPair(b, r)
+	}
+
+	return append(b, ']'), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// It accepts an array of Ranges, each being either a pair or an object
+// (see Range.UnmarshalJSON), or a string in any form that Parse accepts.
+// An array must already be sorted, with no empty, overlapping or adjacent
+// Ranges; otherwise an error is returned and set is left unchanged.
+func (set *RangeSet[E]) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 {
+		return errEmptyJSON
+	}
+
+	if string(data) == "null" {
+		return nil
+	}
+
+	if data[0] == '"' {
+		var text string
+
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+
+		return set.UnmarshalText([]byte(text))
+	}
+
+	var s []Range[E]
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	if err := RangeSet[E](s).Validate(); err != nil {
+		return err
+	}
+
+	*set = s
+
+	return nil
+}
+
+// JSONObjects is a RangeSet that is encoded in JSON as an array of objects,
+// e.g. [{"low":1,"high":6},{"low":7,"high":8}].
+type JSONObjects[E Elem] RangeSet[E]
+
+// MarshalJSON implements the json.Marshaler interface.
+func (set JSONObjects[E]) MarshalJSON() ([]byte, error) {
+	b := append(make([]byte, 0, 24*len(set)+2), '[')
+
+	for i, r := range set {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		b = append(b, `{"low":`...)
+		b = appendElem(b, r.Low)
+		b = append(b, `,"high":`...)
+		b = appendElem(b, r.High)
+		b = append(b, '}')
+	}
+
+	return append(b, ']'), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts everything that RangeSet.UnmarshalJSON accepts.
+func (set *JSONObjects[E]) UnmarshalJSON(data []byte) error {
+	return (*RangeSet[E])(set).UnmarshalJSON(data)
+}
+
+// JSONString is a RangeSet that is encoded in JSON as a string in
+// DashNotation, e.g. "1-5,7".
+type JSONString[E Elem] RangeSet[E]
+
+// MarshalJSON implements the json.Marshaler interface.
+func (set JSONString[E]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(RangeSet[E](set).String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts everything that RangeSet.UnmarshalJSON accepts.
+func (set *JSONString[E]) UnmarshalJSON(data []byte) error {
+	return (*RangeSet[E])(set).UnmarshalJSON(data)
+}
+
+func appendJSONPair[E Elem](b []byte, r Range[E]) []byte {
+	b = append(b, '[')
+	b = appendElem(b, r.Low)
+	b = append(b, ',')
+	b = appendElem(b, r.High)
+	return append(b, ']')
+}
@@ -0,0 +1,140 @@
+package rangeset_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	type E int
+
+	s := RangeSet[E]{{1, 6}, {7, 8}}
+
+	testCases := []struct {
+		Value    any
+		Expected string
+	}{
+		{s, `[[1,6],[7,8]]`},
+		{RangeSet[E](nil), `[]`},
+		{JSONObjects[E](s), `[{"low":1,"high":6},{"low":7,"high":8}]`},
+		{JSONString[E](s), `"1-5,7"`},
+		{Range[E]{-3, 4}, `[-3,4]`},
+	}
+
+	for i, c := range testCases {
+		b, err := json.Marshal(c.Value)
+		if err != nil {
+			t.Fail()
+			t.Logf("Case %v: unexpected error: %v", i, err)
+
+			continue
+		}
+
+		if string(b) != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %s, but got %s", i, c.Expected, b)
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	type E int
+
+	testCases := []struct {
+		Input    string
+		Expected RangeSet[E]
+	}{
+		{`[]`, RangeSet[E]{}},
+		{`[[1,6],[7,8]]`, RangeSet[E]{{1, 6}, {7, 8}}},
+		{`[{"low":1,"high":6},{"Low":7,"High":8}]`, RangeSet[E]{{1, 6}, {7, 8}}},
+		{`[[1,6],{"low":7,"high":8}]`, RangeSet[E]{{1, 6}, {7, 8}}},
+		{`"7,1-5"`, RangeSet[E]{{1, 6}, {7, 8}}},
+	}
+
+	for i, c := range testCases {
+		var s RangeSet[E]
+
+		if err := json.Unmarshal([]byte(c.Input), &s); err != nil {
+			t.Fail()
+			t.Logf("Case %v: unexpected error: %v", i, err)
+
+			continue
+		}
+
+		if !s.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, s)
+		}
+	}
+}
+
+func TestUnmarshalJSON_error(t *testing.T) {
+	type E uint8
+
+	inputs := []string{
+		`[[7,8],[1,6]]`,
+		`[[1,6],[5,8]]`,
+		`[[1,6],[6,8]]`,
+		`[[3,3]]`,
+		`[[6,1]]`,
+		`[[1,2,3]]`,
+		`[{"low":1}]`,
+		`[[1,256]]`,
+		`[1,2]`,
+		`"1-"`,
+		`{}`,
+	}
+
+	for i, input := range inputs {
+		var s RangeSet[E]
+
+		if err := json.Unmarshal([]byte(input), &s); err == nil {
+			t.Fail()
+			t.Logf("Case %v: want an error for %s, but got %v", i, input, s)
+		}
+	}
+
+	var (
+		r Range[E]
+		s RangeSet[E]
+	)
+
+	if err := r.UnmarshalJSON(nil); err == nil {
+		t.Fatal("want an error for empty input to Range, but got nil")
+	}
+
+	if err := s.UnmarshalJSON(nil); err == nil {
+		t.Fatal("want an error for empty input to RangeSet, but got nil")
+	}
+}
+
+func TestJSONShapes_roundTrip(t *testing.T) {
+	type E int64
+
+	type Config struct {
+		A RangeSet[E]
+		B JSONObjects[E]
+		C JSONString[E]
+	}
+
+	s := RangeSet[E]{{-10, -5}, {0, 1}, {100, 200}}
+	in := Config{s, JSONObjects[E](s), JSONString[E](s)}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out Config
+
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if !out.A.Equal(s) || !RangeSet[E](out.B).Equal(s) || !RangeSet[E](out.C).Equal(s) {
+		t.Fail()
+		t.Logf("round trip of %s produced %+v", b, out)
+	}
+}
@@ -7,9 +7,34 @@ func maxOf[E Elem]() E {
 }
 
 func minOf[E Elem]() E {
-	if ^E(0) > 0 {
+	if !isSigned[E]() {
 		return 0
 	}
 
-	return E(1) << (unsafe.Sizeof(E(0))*8 - 1)
+	return E(1) << (bitSize[E]() - 1)
+}
+
+func isSigned[E Elem]() bool {
+	return ^E(0) < 0
+}
+
+func bitSize[E Elem]() int {
+	return int(unsafe.Sizeof(E(0)) * 8)
+}
+
+// rangeSize returns the number of elements in r, which must not be empty.
+// The subtraction is done in unsigned arithmetic, so the result is correct
+// even if r.High-r.Low overflows E.
+func rangeSize[E Elem](r Range[E]) uint64 {
+	return offsetOf(r.High) - offsetOf(r.Low)
+}
+
+// offsetOf returns the distance from the minimum value of E to v.
+func offsetOf[E Elem](v E) uint64 {
+	return uint64(v-minOf[E]()) & (^uint64(0) >> (64 - bitSize[E]()))
+}
+
+// elemOf is the inverse of offsetOf.
+func elemOf[E Elem](off uint64) E {
+	return E(off) + minOf[E]()
 }
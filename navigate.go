@@ -0,0 +1,95 @@
+package rangeset
+
+import "sort"
+
+// Ceiling returns the smallest element in set that is greater than or
+// equal to v.
+//
+// If there is no such element, Ceiling returns the zero value and false.
+func (set RangeSet[E]) Ceiling(v E) (E, bool) {
+	i := sort.Search(len(set), func(i int) bool { return set[i].High > v })
+
+	if i == len(set) {
+		return 0, false
+	}
+
+	if r := set[i]; r.Low > v {
+		return r.Low, true
+	}
+
+	return v, true
+}
+
+// Floor returns the largest element in set that is less than or equal to v.
+//
+// If there is no such element, Floor returns the zero value and false.
+func (set RangeSet[E]) Floor(v E) (E, bool) {
+	i := sort.Search(len(set), func(i int) bool { return set[i].Low > v })
+
+	if i == 0 {
+		return 0, false
+	}
+
+	if r := set[i-1]; r.High <= v {
+		return r.High - 1, true
+	}
+
+	return v, true
+}
+
+// NextGap returns the smallest value that is greater than or equal to v
+// but not in set.
+//
+// Since a RangeSet never contains the maximum value of E, such a value
+// always exists.
+func (set RangeSet[E]) NextGap(v E) E {
+	i := sort.Search(len(set), func(i int) bool { return set[i].High > v })
+
+	if i < len(set) && set[i].Low <= v {
+		return set[i].High
+	}
+
+	return v
+}
+
+// RangeContaining returns the Range in set that contains v.
+//
+// If set does not contain v, RangeContaining returns the zero value and
+// false.
+func (set RangeSet[E]) RangeContaining(v E) (Range[E], bool) {
+	i := sort.Search(len(set), func(i int) bool { return set[i].High > v })
+
+	if i < len(set) && set[i].Low <= v {
+		return set[i], true
+	}
+
+	return Range[E]{}, false
+}
+
+// NextRange returns the first Range in set that lies entirely after v,
+// i.e. whose Low is greater than v.
+//
+// If there is no such Range, NextRange returns the zero value and false.
+func (set RangeSet[E]) NextRange(v E) (Range[E], bool) {
+	i := sort.Search(len(set), func(i int) bool { return set[i].Low > v })
+
+	if i == len(set) {
+		return Range[E]{}, false
+	}
+
+	return set[i], true
+}
+
+// PrevRange returns the last Range in set that lies entirely before v,
+// i.e. whose High is less than or equal to v.
+//
+// If there is no such Range, PrevRange returns the zero value and false.
+func (set RangeSet[E]) PrevRange(v E) (Range[E], bool) {
+	i := sort.Search(len(set), func(i int) bool { return set[i].High > v })
+
+	if i == 0 {
+		return Range[E]{}, false
+	}
+
+	return set[i-1], true
+}
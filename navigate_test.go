@@ -0,0 +1,105 @@
+package rangeset_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestCeilingFloor(t *testing.T) {
+	type E int
+
+	s := RangeSet[E]{{1, 4}, {6, 8}}
+
+	type result struct {
+		V  E
+		OK bool
+	}
+
+	wrap := func(v E, ok bool) result { return result{v, ok} }
+
+	testCases := []struct {
+		Result, Expected result
+	}{
+		{wrap(s.Ceiling(0)), result{1, true}},
+		{wrap(s.Ceiling(2)), result{2, true}},
+		{wrap(s.Ceiling(4)), result{6, true}},
+		{wrap(s.Ceiling(7)), result{7, true}},
+		{wrap(s.Ceiling(8)), result{0, false}},
+		{wrap(s.Floor(0)), result{0, false}},
+		{wrap(s.Floor(1)), result{1, true}},
+		{wrap(s.Floor(5)), result{3, true}},
+		{wrap(s.Floor(6)), result{6, true}},
+		{wrap(s.Floor(100)), result{7, true}},
+		{wrap(RangeSet[E]{}.Ceiling(0)), result{0, false}},
+		{wrap(RangeSet[E]{}.Floor(0)), result{0, false}},
+	}
+
+	for i, c := range testCases {
+		if c.Result != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
+
+func TestNextGap(t *testing.T) {
+	type E uint8
+
+	s := RangeSet[E]{{1, 4}, {6, 8}, {200, math.MaxUint8}}
+
+	testCases := []struct {
+		Result, Expected E
+	}{
+		{s.NextGap(0), 0},
+		{s.NextGap(1), 4},
+		{s.NextGap(3), 4},
+		{s.NextGap(5), 5},
+		{s.NextGap(6), 8},
+		{s.NextGap(210), math.MaxUint8},
+	}
+
+	for i, c := range testCases {
+		if c.Result != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
+
+func TestRangeLookup(t *testing.T) {
+	type E int
+
+	s := RangeSet[E]{{1, 4}, {6, 8}}
+
+	type result struct {
+		R  Range[E]
+		OK bool
+	}
+
+	wrap := func(r Range[E], ok bool) result { return result{r, ok} }
+
+	testCases := []struct {
+		Result, Expected result
+	}{
+		{wrap(s.RangeContaining(0)), result{Range[E]{}, false}},
+		{wrap(s.RangeContaining(1)), result{Range[E]{1, 4}, true}},
+		{wrap(s.RangeContaining(4)), result{Range[E]{}, false}},
+		{wrap(s.RangeContaining(7)), result{Range[E]{6, 8}, true}},
+		{wrap(s.NextRange(0)), result{Range[E]{1, 4}, true}},
+		{wrap(s.NextRange(1)), result{Range[E]{6, 8}, true}},
+		{wrap(s.NextRange(6)), result{Range[E]{}, false}},
+		{wrap(s.PrevRange(3)), result{Range[E]{}, false}},
+		{wrap(s.PrevRange(4)), result{Range[E]{1, 4}, true}},
+		{wrap(s.PrevRange(7)), result{Range[E]{1, 4}, true}},
+		{wrap(s.PrevRange(8)), result{Range[E]{6, 8}, true}},
+	}
+
+	for i, c := range testCases {
+		if c.Result != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package rangeset
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// A Violation is a kind of RangeSet invariant violation.
+type Violation int
+
+const (
+	// EmptyRange means a Range has Low >= High.
+	EmptyRange Violation = iota + 1
+
+	// UnsortedRange means a Range has a smaller Low than its predecessor.
+	UnsortedRange
+
+	// OverlappingRange means a Range overlaps its predecessor.
+	OverlappingRange
+
+	// AdjacentRange means a Range adjoins its predecessor, i.e. its Low
+	// equals its predecessor's High. Adjacent Ranges should have been
+	// merged into one.
+	AdjacentRange
+)
+
+func (v Violation) String() string {
+	switch v {
+	case EmptyRange:
+		return "empty"
+	case UnsortedRange:
+		return "out of order"
+	case OverlappingRange:
+		return "overlapping its predecessor"
+	case AdjacentRange:
+		return "adjacent to its predecessor"
+	}
+
+	return "Violation(" + strconv.Itoa(int(v)) + ")"
+}
+
+// A ValidationError describes the first violation of RangeSet invariants
+// found by Validate.
+type ValidationError struct {
+	Index int       // Index of the offending Range.
+	Kind  Violation // What is wrong with it.
+	Range string    // The offending Range, in the form "[lo,hi)".
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("rangeset: range %d %s is %v", e.Index, e.Range, e.Kind)
+}
+
+// Validate reports whether set satisfies the invariants of a RangeSet: every
+// Range is non-empty, and Ranges are sorted in ascending order with neither
+// overlapping nor adjacent ones. Every method of RangeSet assumes that.
+//
+// If set is invalid, Validate returns a *ValidationError describing the
+// first violation found.
+func (set RangeSet[E]) Validate() error {
+	for i, r := range set {
+		var kind Violation
+
+		switch {
+		case r.Low >= r.High:
+			kind = EmptyRange
+		case i == 0:
+		case r.Low < set[i-1].Low:
+			kind = UnsortedRange
+		case r.Low < set[i-1].High:
+			kind = OverlappingRange
+		case r.Low == set[i-1].High:
+			kind = AdjacentRange
+		}
+
+		if kind != 0 {
+			return &ValidationError{i, kind, string(appendRange(nil, r))}
+		}
+	}
+
+	return nil
+}
+
+// Normalize turns set, which can be an arbitrary slice of Ranges, into
+// a valid RangeSet: Ranges are sorted, empty ones are dropped, overlapping
+// or adjacent ones are merged.
+//
+// Normalize works in place and takes O(n log n) time.
+func (set *RangeSet[E]) Normalize() {
+	*set = normalize(*set)
+}
+
+// normalize is like Normalize, but returns the result instead. The result
+// shares the backing storage of s.
+func normalize[E Elem](s RangeSet[E]) RangeSet[E] {
+	if s.Validate() == nil {
+		return s
+	}
+
+	sort.Slice(s, func(i, j int) bool { return s[i].Low < s[j].Low })
+
+	res := s[:0]
+
+	for _, r := range s {
+		if r.Low >= r.High {
+			continue
+		}
+
+		if n := len(res); n > 0 && r.Low <= res[n-1].High {
+			if r.High > res[n-1].High {
+				res[n-1].High = r.High
+			}
+
+			continue
+		}
+
+		res = append(res, r)
+	}
+
+	return res
+}
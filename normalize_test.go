@@ -0,0 +1,100 @@
+package rangeset_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestValidate(t *testing.T) {
+	type E int
+
+	testCases := []struct {
+		Input RangeSet[E]
+		Index int
+		Kind  Violation
+	}{
+		{RangeSet[E]{}, 0, 0},
+		{RangeSet[E]{{1, 3}, {5, 7}}, 0, 0},
+		{RangeSet[E]{{1, 1}}, 0, EmptyRange},
+		{RangeSet[E]{{1, 3}, {7, 5}}, 1, EmptyRange},
+		{RangeSet[E]{{5, 7}, {1, 3}}, 1, UnsortedRange},
+		{RangeSet[E]{{1, 3}, {5, 7}, {6, 9}}, 2, OverlappingRange},
+		{RangeSet[E]{{1, 3}, {1, 2}}, 1, OverlappingRange},
+		{RangeSet[E]{{1, 3}, {3, 7}}, 1, AdjacentRange},
+	}
+
+	for i, c := range testCases {
+		err := c.Input.Validate()
+
+		if c.Kind == 0 {
+			if err != nil {
+				t.Fail()
+				t.Logf("Case %v: unexpected error: %v", i, err)
+			}
+
+			continue
+		}
+
+		var e *ValidationError
+
+		if !errors.As(err, &e) || e.Index != c.Index || e.Kind != c.Kind {
+			t.Fail()
+			t.Logf("Case %v: want violation %v at %v, but got %v", i, c.Kind, c.Index, err)
+		}
+	}
+
+	err := RangeSet[E]{{1, 3}, {3, 7}}.Validate()
+
+	if s := err.Error(); s != "rangeset: range 1 [3,7) is adjacent to its predecessor" {
+		t.Fatalf("unexpected error message: %v", s)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	type E int
+
+	normalize := func(s RangeSet[E]) RangeSet[E] {
+		s.Normalize()
+		return s
+	}
+
+	testCases := []struct {
+		Result, Expected RangeSet[E]
+	}{
+		{normalize(RangeSet[E]{}), RangeSet[E]{}},
+		{normalize(RangeSet[E]{{1, 3}, {5, 7}}), RangeSet[E]{{1, 3}, {5, 7}}},
+		{normalize(RangeSet[E]{{5, 7}, {1, 3}}), RangeSet[E]{{1, 3}, {5, 7}}},
+		{normalize(RangeSet[E]{{5, 7}, {1, 3}, {3, 5}}), RangeSet[E]{{1, 7}}},
+		{normalize(RangeSet[E]{{1, 10}, {2, 3}, {9, 12}}), RangeSet[E]{{1, 12}}},
+		{normalize(RangeSet[E]{{4, 4}, {9, 2}, {1, 2}}), RangeSet[E]{{1, 2}}},
+	}
+
+	for i, c := range testCases {
+		if !c.Result.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+
+		if err := c.Result.Validate(); err != nil {
+			t.Fail()
+			t.Logf("Case %v: %v", i, err)
+		}
+	}
+}
+
+func TestUnmarshalJSON_validationError(t *testing.T) {
+	type E int
+
+	var s RangeSet[E]
+
+	err := json.Unmarshal([]byte(`[[1,3],[2,5]]`), &s)
+
+	var e *ValidationError
+
+	if !errors.As(err, &e) || e.Index != 1 || e.Kind != OverlappingRange {
+		t.Fatalf("want an OverlappingRange violation at 1, but got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package rangeset
+
+import "iter"
+
+// A PersistentRangeSet is an immutable set of discrete Ranges.
+//
+// Methods that would modify a PersistentRangeSet return a new version
+// instead, which shares most of its structure with the old one. Taking
+// a snapshot is therefore O(1), adding or deleting a Range is O(log n),
+// and every version stays valid and can be read from multiple goroutines
+// concurrently without locking.
+//
+// The zero value for a PersistentRangeSet is an empty set.
+type PersistentRangeSet[E Elem] struct {
+	tree TreeRangeSet[E]
+}
+
+// NewPersistentRangeSet creates a PersistentRangeSet containing the same
+// elements as set.
+func NewPersistentRangeSet[E Elem](set RangeSet[E]) PersistentRangeSet[E] {
+	return PersistentRangeSet[E]{NewTreeRangeSet(set)}
+}
+
+// Snapshot returns a PersistentRangeSet containing the same elements as t.
+// Later changes made to t do not affect the snapshot.
+func (t TreeRangeSet[E]) Snapshot() PersistentRangeSet[E] {
+	return PersistentRangeSet[E]{t}
+}
+
+// Tree returns a TreeRangeSet containing the same elements as s, which can
+// then be modified in place without affecting s.
+func (s PersistentRangeSet[E]) Tree() TreeRangeSet[E] {
+	return s.tree
+}
+
+// RangeSet returns a RangeSet containing the same elements as s.
+func (s PersistentRangeSet[E]) RangeSet() RangeSet[E] {
+	return s.tree.RangeSet()
+}
+
+// Add returns a new version of s with a single element added.
+func (s PersistentRangeSet[E]) Add(v E) PersistentRangeSet[E] {
+	s.tree.Add(v)
+	return s
+}
+
+// AddRange returns a new version of s with range [lo, hi) added.
+func (s PersistentRangeSet[E]) AddRange(lo, hi E) PersistentRangeSet[E] {
+	s.tree.AddRange(lo, hi)
+	return s
+}
+
+// Delete returns a new version of s with a single element removed.
+func (s PersistentRangeSet[E]) Delete(v E) PersistentRangeSet[E] {
+	s.tree.Delete(v)
+	return s
+}
+
+// DeleteRange returns a new version of s with range [lo, hi) removed.
+func (s PersistentRangeSet[E]) DeleteRange(lo, hi E) PersistentRangeSet[E] {
+	s.tree.DeleteRange(lo, hi)
+	return s
+}
+
+// Contains reports whether s contains a single element.
+func (s PersistentRangeSet[E]) Contains(v E) bool {
+	return s.tree.Contains(v)
+}
+
+// ContainsRange reports whether s contains every element in range [lo, hi).
+func (s PersistentRangeSet[E]) ContainsRange(lo, hi E) bool {
+	return s.tree.ContainsRange(lo, hi)
+}
+
+// Count returns the number of element in s.
+func (s PersistentRangeSet[E]) Count() uint64 {
+	return s.tree.Count()
+}
+
+// Len returns the number of Ranges in s.
+func (s PersistentRangeSet[E]) Len() int {
+	return s.tree.Len()
+}
+
+// Equal reports whether s is identical to other.
+func (s PersistentRangeSet[E]) Equal(other PersistentRangeSet[E]) bool {
+	return s.tree.Equal(other.tree)
+}
+
+// Extent returns the smallest Range that covers the whole set.
+//
+// If s is empty, Extent returns the zero value.
+func (s PersistentRangeSet[E]) Extent() Range[E] {
+	return s.tree.Extent()
+}
+
+// Union returns the union of s and other.
+func (s PersistentRangeSet[E]) Union(other PersistentRangeSet[E]) PersistentRangeSet[E] {
+	return PersistentRangeSet[E]{s.tree.Union(other.tree)}
+}
+
+// Intersection returns the intersection of s and other.
+func (s PersistentRangeSet[E]) Intersection(other PersistentRangeSet[E]) PersistentRangeSet[E] {
+	return PersistentRangeSet[E]{s.tree.Intersection(other.tree)}
+}
+
+// Difference returns the subset of s that having all elements in other
+// excluded.
+func (s PersistentRangeSet[E]) Difference(other PersistentRangeSet[E]) PersistentRangeSet[E] {
+	return PersistentRangeSet[E]{s.tree.Difference(other.tree)}
+}
+
+// Complement returns the inverse of s.
+func (s PersistentRangeSet[E]) Complement() PersistentRangeSet[E] {
+	return PersistentRangeSet[E]{s.tree.Complement()}
+}
+
+// Ranges returns an iterator over Ranges in s, in ascending order.
+func (s PersistentRangeSet[E]) Ranges() iter.Seq[Range[E]] {
+	return s.tree.Ranges()
+}
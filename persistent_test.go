@@ -0,0 +1,101 @@
+package rangeset_test
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestPersistentRangeSet(t *testing.T) {
+	type E int
+
+	v0 := PersistentRangeSet[E]{}
+	v1 := v0.AddRange(1, 5)
+	v2 := v1.AddRange(7, 9)
+	v3 := v2.DeleteRange(2, 8)
+	v4 := v3.Add(5).Delete(1)
+
+	testCases := []struct {
+		Result, Expected RangeSet[E]
+	}{
+		{v0.RangeSet(), RangeSet[E]{}},
+		{v1.RangeSet(), RangeSet[E]{{1, 5}}},
+		{v2.RangeSet(), RangeSet[E]{{1, 5}, {7, 9}}},
+		{v3.RangeSet(), RangeSet[E]{{1, 2}, {8, 9}}},
+		{v4.RangeSet(), RangeSet[E]{{5, 6}, {8, 9}}},
+		{v2.Union(v4).RangeSet(), RangeSet[E]{{1, 6}, {7, 9}}},
+		{v2.Intersection(v4).RangeSet(), RangeSet[E]{{8, 9}}},
+		{v2.Difference(v4).RangeSet(), RangeSet[E]{{1, 5}, {7, 8}}},
+		{v1.RangeSet(), RangeSet[E]{{1, 5}}},
+	}
+
+	for i, c := range testCases {
+		if !c.Result.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+
+	assertions := []bool{
+		v2.Contains(8),
+		!v3.Contains(7),
+		v2.ContainsRange(1, 5),
+		v2.Count() == 6,
+		v2.Len() == 2,
+		v2.Extent() == Range[E]{1, 9},
+		v2.Equal(NewPersistentRangeSet(RangeSet[E]{{1, 5}, {7, 9}})),
+		!v2.Equal(v3),
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
+
+func TestPersistentRangeSet_snapshot(t *testing.T) {
+	type E uint32
+
+	var live TreeRangeSet[E]
+
+	live.AddRange(0, 100)
+
+	snap := live.Snapshot()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 1000; j++ {
+				if !snap.ContainsRange(0, 100) || snap.Count() != 100 {
+					t.Error("snapshot changed")
+					return
+				}
+			}
+		}()
+	}
+
+	for i := E(0); i < 100; i += 2 {
+		live.Delete(i)
+	}
+
+	wg.Wait()
+
+	if live.Count() != 50 || snap.Count() != 100 {
+		t.Fatalf("want 50 and 100 elements, but got %v and %v", live.Count(), snap.Count())
+	}
+
+	tree := snap.Tree()
+	tree.AddRange(200, 300)
+
+	if snap.Count() != 100 || tree.Count() != 200 {
+		t.Fatalf("want 100 and 200 elements, but got %v and %v", snap.Count(), tree.Count())
+	}
+}
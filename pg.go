@@ -0,0 +1,234 @@
+package rangeset
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// PGRange is a Range that can be read from and written to a PostgreSQL
+// range column (e.g. int4range, int8range) through database/sql.
+//
+// An empty Range is written as "empty". When reading, an unbounded lower
+// endpoint maps to the minimum value of E, and an unbounded upper endpoint
+// maps to the maximum value of E (exclusive).
+type PGRange[E Elem] Range[E]
+
+// Scan implements the sql.Scanner interface.
+// A NULL value is scanned as an empty Range.
+func (r *PGRange[E]) Scan(src any) error {
+	text, err := pgText(src)
+	if err != nil {
+		return err
+	}
+
+	if text == "" {
+		*r = PGRange[E]{}
+		return nil
+	}
+
+	v, err := parsePGRange[E](text)
+	if err != nil {
+		return err
+	}
+
+	*r = PGRange[E](v)
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (r PGRange[E]) Value() (driver.Value, error) {
+	if r.Low >= r.High {
+		return "empty", nil
+	}
+
+	return string(appendRange(nil, Range[E](r))), nil
+}
+
+// PGMultirange is a RangeSet that can be read from and written to
+// a PostgreSQL multirange column (e.g. int4multirange, int8multirange)
+// through database/sql, using literals like "{[1,5),[7,8)}".
+//
+// When reading, any bracket variants, "empty" elements and unbounded
+// endpoints are accepted (see PGRange), and the result is sorted and
+// merged.
+type PGMultirange[E Elem] RangeSet[E]
+
+// Scan implements the sql.Scanner interface.
+// A NULL value is scanned as an empty set.
+func (set *PGMultirange[E]) Scan(src any) error {
+	text, err := pgText(src)
+	if err != nil {
+		return err
+	}
+
+	if text == "" {
+		*set = nil
+		return nil
+	}
+
+	if len(text) < 2 || text[0] != '{' || text[len(text)-1] != '}' {
+		return fmt.Errorf("rangeset: invalid multirange literal %q", text)
+	}
+
+	var s RangeSet[E]
+
+	inner := strings.TrimSpace(text[1 : len(text)-1])
+
+	for more := inner != ""; more; {
+		var item string
+
+		item, inner, more = nextPGItem(inner)
+		item = strings.TrimSpace(item)
+
+		if item == "" {
+			return fmt.Errorf("rangeset: invalid multirange literal %q: %w", text, errEmptyItem)
+		}
+
+		r, err := parsePGRange[E](item)
+		if err != nil {
+			return err
+		}
+
+		s = append(s, r)
+	}
+
+	*set = PGMultirange[E](normalize(s))
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (set PGMultirange[E]) Value() (driver.Value, error) {
+	b := append(make([]byte, 0, 16*len(set)+2), '{')
+
+	for i, r := range set {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		b = appendRange(b, r)
+	}
+
+	return string(append(b, '}')), nil
+}
+
+func pgText(src any) (string, error) {
+	switch src := src.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return strings.TrimSpace(src), nil
+	case []byte:
+		return strings.TrimSpace(string(src)), nil
+	default:
+		return "", fmt.Errorf("rangeset: cannot scan %T into a range", src)
+	}
+}
+
+// nextPGItem splits off the first comma-separated range literal from s,
+// and reports whether a comma follows it.
+func nextPGItem(s string) (item, rest string, more bool) {
+	inRange := false
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[', '(':
+			inRange = true
+		case ']', ')':
+			inRange = false
+		case ',':
+			if !inRange {
+				return s[:i], s[i+1:], true
+			}
+		}
+	}
+
+	return s, "", false
+}
+
+// parsePGRange parses a PostgreSQL range literal, e.g. "[1,5)", "(1,5]",
+// "[1,)" or "empty". An empty range is returned as the zero Range.
+func parsePGRange[E Elem](text string) (r Range[E], err error) {
+	if strings.EqualFold(text, "empty") {
+		return Range[E]{}, nil
+	}
+
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("rangeset: parsing range literal %q: %w", text, err)
+		}
+	}()
+
+	if len(text) < 2 {
+		return r, errUnbalanced
+	}
+
+	open, closing := text[0], text[len(text)-1]
+	if (open != '[' && open != '(') || (closing != ']' && closing != ')') {
+		return r, errUnbalanced
+	}
+
+	inner := text[1 : len(text)-1]
+
+	i := strings.IndexByte(inner, ',')
+	if i < 0 {
+		return r, errMissingComma
+	}
+
+	lo, hi := pgBound(inner[:i]), pgBound(inner[i+1:])
+
+	r.Low, r.High = minOf[E](), maxOf[E]()
+
+	if lo != "" {
+		if r.Low, err = parseElem[E](lo); err != nil {
+			return
+		}
+	}
+
+	if hi != "" {
+		if r.High, err = parseElem[E](hi); err != nil {
+			return
+		}
+	}
+
+	// Like PostgreSQL, reject reversed bounds, but not bounds that merely
+	// make the range empty once exclusivity is taken into account.
+	if lo != "" && hi != "" && r.Low > r.High {
+		return Range[E]{}, errReversed
+	}
+
+	if lo != "" && open == '(' {
+		if r.Low == maxOf[E]() {
+			return Range[E]{}, nil
+		}
+
+		r.Low++
+	}
+
+	if hi != "" && closing == ']' {
+		if r.High == maxOf[E]() {
+			return r, errOutOfDomain
+		}
+
+		r.High++
+	}
+
+	if r.Low >= r.High {
+		return Range[E]{}, nil
+	}
+
+	return r, nil
+}
+
+// pgBound trims whitespace and optional double quotes around a bound.
+func pgBound(s string) string {
+	s = strings.TrimSpace(s)
+
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+
+	return s
+}
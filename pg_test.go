@@ -0,0 +1,155 @@
+package rangeset_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestPGMultirange_Scan(t *testing.T) {
+	type E int32
+
+	testCases := []struct {
+		Input    any
+		Expected RangeSet[E]
+	}{
+		{nil, RangeSet[E]{}},
+		{"{}", RangeSet[E]{}},
+		{"{[1,5),[7,8)}", RangeSet[E]{{1, 5}, {7, 8}}},
+		{[]byte("{[1,5),[7,8)}"), RangeSet[E]{{1, 5}, {7, 8}}},
+		{"{ (0,4] , [7,7] }", RangeSet[E]{{1, 5}, {7, 8}}},
+		{"{[7,8),empty,(1,5)}", RangeSet[E]{{2, 5}, {7, 8}}},
+		{"{[1,5),[3,9)}", RangeSet[E]{{1, 9}}},
+		{"{(,0),[10,)}", RangeSet[E]{{math.MinInt32, 0}, {10, math.MaxInt32}}},
+		{`{["1","5")}`, RangeSet[E]{{1, 5}}},
+		{"{(4,5)}", RangeSet[E]{}},
+	}
+
+	for i, c := range testCases {
+		var s PGMultirange[E]
+
+		if err := s.Scan(c.Input); err != nil {
+			t.Fail()
+			t.Logf("Case %v: unexpected error: %v", i, err)
+
+			continue
+		}
+
+		if !RangeSet[E](s).Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, RangeSet[E](s))
+		}
+	}
+}
+
+func TestPGMultirange_Scan_error(t *testing.T) {
+	type E int8
+
+	inputs := []any{
+		42,
+		"[1,5)",
+		"{[1,5)",
+		"{[1;5)}",
+		"{[1,5}",
+		"{[a,5)}",
+		"{[1,127]}",
+		"{[1,128)}",
+		"{[1,2),}",
+		"{[5,1)}",
+		"{(5,1]}",
+		"{,[1,2)}",
+		"{[1,2),,[3,4)}",
+	}
+
+	for i, input := range inputs {
+		var s PGMultirange[E]
+
+		if err := s.Scan(input); err == nil {
+			t.Fail()
+			t.Logf("Case %v: want an error for %v, but got %v", i, input, RangeSet[E](s))
+		}
+	}
+}
+
+func TestPGMultirange_Value(t *testing.T) {
+	type E int64
+
+	testCases := []struct {
+		Input    RangeSet[E]
+		Expected string
+	}{
+		{RangeSet[E]{}, "{}"},
+		{RangeSet[E]{{1, 5}, {7, 8}}, "{[1,5),[7,8)}"},
+		{RangeSet[E]{{-3, 0}}, "{[-3,0)}"},
+	}
+
+	for i, c := range testCases {
+		v, err := PGMultirange[E](c.Input).Value()
+		if err != nil {
+			t.Fail()
+			t.Logf("Case %v: unexpected error: %v", i, err)
+
+			continue
+		}
+
+		if v != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %q, but got %q", i, c.Expected, v)
+		}
+	}
+}
+
+func TestPGRange(t *testing.T) {
+	type E int
+
+	testCases := []struct {
+		Input    string
+		Expected Range[E]
+		Value    string
+	}{
+		{"[1,5)", Range[E]{1, 5}, "[1,5)"},
+		{"(1,5]", Range[E]{2, 6}, "[2,6)"},
+		{"[3,3)", Range[E]{}, "empty"},
+		{"(3,4)", Range[E]{}, "empty"},
+		{"(3,3]", Range[E]{}, "empty"},
+		{"EMPTY", Range[E]{}, "empty"},
+		{"[,0)", Range[E]{math.MinInt, 0}, "[-9223372036854775808,0)"},
+	}
+
+	for i, c := range testCases {
+		var r PGRange[E]
+
+		if err := r.Scan(c.Input); err != nil {
+			t.Fail()
+			t.Logf("Case %v: unexpected error: %v", i, err)
+
+			continue
+		}
+
+		if Range[E](r) != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, r)
+		}
+
+		if v, _ := r.Value(); v != c.Value {
+			t.Fail()
+			t.Logf("Case %v: want %q, but got %q", i, c.Value, v)
+		}
+	}
+}
+
+func TestPGRange_Scan_error(t *testing.T) {
+	type E int8
+
+	inputs := []any{"[5,1)", "(5,1]", "[1,127]", "[1;5)", 42}
+
+	for i, input := range inputs {
+		var r PGRange[E]
+
+		if err := r.Scan(input); err == nil {
+			t.Fail()
+			t.Logf("Case %v: want an error for %v, but got %v", i, input, Range[E](r))
+		}
+	}
+}
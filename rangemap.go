@@ -0,0 +1,241 @@
+package rangeset
+
+import (
+	"iter"
+	"sort"
+)
+
+// A RangeMap maps disjoint Ranges of E to values of V.
+//
+// Entries are kept sorted in ascending order. Adjacent entries never carry
+// equal values; they are coalesced into one.
+//
+// The zero value for a RangeMap is an empty map.
+type RangeMap[E Elem, V comparable] struct {
+	entries []rangeMapEntry[E, V]
+}
+
+type rangeMapEntry[E Elem, V comparable] struct {
+	Range[E]
+	Value V
+}
+
+// Set maps every element in range [lo, hi) to v, overwriting existing
+// mappings in that range.
+func (m *RangeMap[E, V]) Set(lo, hi E, v V) {
+	if lo >= hi {
+		return
+	}
+
+	s := m.entries
+
+	i := sort.Search(len(s), func(i int) bool { return s[i].High > lo })
+	j := i + sort.Search(len(s)-i, func(k int) bool { return s[i+k].Low >= hi })
+
+	// Entries s[i:j] overlap [lo, hi). The first and the last of them may
+	// stick out on either side, in which case they are trimmed, not removed.
+
+	mid := make([]rangeMapEntry[E, V], 0, 3)
+
+	if i < j && s[i].Low < lo {
+		mid = append(mid, rangeMapEntry[E, V]{Range[E]{s[i].Low, lo}, s[i].Value})
+	}
+
+	mid = append(mid, rangeMapEntry[E, V]{Range[E]{lo, hi}, v})
+
+	if i < j && s[j-1].High > hi {
+		mid = append(mid, rangeMapEntry[E, V]{Range[E]{hi, s[j-1].High}, s[j-1].Value})
+	}
+
+	m.splice(i, j, mid)
+}
+
+// Delete removes mappings for every element in range [lo, hi).
+func (m *RangeMap[E, V]) Delete(lo, hi E) {
+	if lo >= hi {
+		return
+	}
+
+	s := m.entries
+
+	i := sort.Search(len(s), func(i int) bool { return s[i].High > lo })
+	j := i + sort.Search(len(s)-i, func(k int) bool { return s[i+k].Low >= hi })
+
+	if i == j {
+		return
+	}
+
+	mid := make([]rangeMapEntry[E, V], 0, 2)
+
+	if s[i].Low < lo {
+		mid = append(mid, rangeMapEntry[E, V]{Range[E]{s[i].Low, lo}, s[i].Value})
+	}
+
+	if s[j-1].High > hi {
+		mid = append(mid, rangeMapEntry[E, V]{Range[E]{hi, s[j-1].High}, s[j-1].Value})
+	}
+
+	m.splice(i, j, mid)
+}
+
+// update replaces, for every element in range [lo, hi), its value with
+// what f returns. f is called once for each maximal subrange with a single
+// value, or with no value (ok is false). If f returns false, the subrange
+// is left unmapped.
+func (m *RangeMap[E, V]) update(lo, hi E, f func(v V, ok bool) (V, bool)) {
+	if lo >= hi {
+		return
+	}
+
+	s := m.entries
+
+	i := sort.Search(len(s), func(i int) bool { return s[i].High > lo })
+	j := i + sort.Search(len(s)-i, func(k int) bool { return s[i+k].Low >= hi })
+
+	mid := make([]rangeMapEntry[E, V], 0, 2*(j-i)+3)
+
+	if i < j && s[i].Low < lo {
+		mid = append(mid, rangeMapEntry[E, V]{Range[E]{s[i].Low, lo}, s[i].Value})
+	}
+
+	var zero V
+
+	cur := lo
+
+	for _, e := range s[i:j] {
+		if cur < e.Low {
+			if v, ok := f(zero, false); ok {
+				mid = append(mid, rangeMapEntry[E, V]{Range[E]{cur, e.Low}, v})
+			}
+
+			cur = e.Low
+		}
+
+		end := e.High
+		if end > hi {
+			end = hi
+		}
+
+		if v, ok := f(e.Value, true); ok {
+			mid = append(mid, rangeMapEntry[E, V]{Range[E]{cur, end}, v})
+		}
+
+		cur = end
+	}
+
+	if cur < hi {
+		if v, ok := f(zero, false); ok {
+			mid = append(mid, rangeMapEntry[E, V]{Range[E]{cur, hi}, v})
+		}
+	}
+
+	if i < j && s[j-1].High > hi {
+		mid = append(mid, rangeMapEntry[E, V]{Range[E]{hi, s[j-1].High}, s[j-1].Value})
+	}
+
+	m.splice(i, j, mid)
+}
+
+// splice replaces entries[i:j] with mid, then coalesces mid with its
+// neighbors where they are adjacent and carry equal values.
+func (m *RangeMap[E, V]) splice(i, j int, mid []rangeMapEntry[E, V]) {
+	s := m.entries
+
+	// Extend the window to the neighbors, so that coalescing can be done
+	// within mid only.
+	if i > 0 {
+		i--
+		mid = append([]rangeMapEntry[E, V]{s[i]}, mid...)
+	}
+
+	if j < len(s) {
+		mid = append(mid, s[j])
+		j++
+	}
+
+	merged := mid[:0]
+
+	for _, e := range mid {
+		if n := len(merged); n > 0 && merged[n-1].High == e.Low && merged[n-1].Value == e.Value {
+			merged[n-1].High = e.High
+			continue
+		}
+
+		merged = append(merged, e)
+	}
+
+	switch d := len(merged) - (j - i); {
+	case d > 0:
+		s = append(s, make([]rangeMapEntry[E, V], d)...)
+		copy(s[j+d:], s[j:])
+	case d < 0:
+		s = append(s[:j+d], s[j:]...)
+	}
+
+	copy(s[i:], merged)
+	m.entries = s
+}
+
+// Get returns the value that v maps to.
+//
+// If v is not mapped, Get returns the zero value and false.
+func (m RangeMap[E, V]) Get(v E) (V, bool) {
+	s := m.entries
+
+	i := sort.Search(len(s), func(i int) bool { return s[i].High > v })
+
+	if i < len(s) && s[i].Low <= v {
+		return s[i].Value, true
+	}
+
+	var zero V
+
+	return zero, false
+}
+
+// Len returns the number of entries in m.
+func (m RangeMap[E, V]) Len() int {
+	return len(m.entries)
+}
+
+// All returns an iterator over entries in m, in ascending order.
+// Each entry is a Range of E and the value that every element in the Range
+// maps to.
+func (m RangeMap[E, V]) All() iter.Seq2[Range[E], V] {
+	return func(yield func(Range[E], V) bool) {
+		for _, e := range m.entries {
+			if !yield(e.Range, e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a RangeSet containing every element that is mapped in m.
+func (m RangeMap[E, V]) Keys() RangeSet[E] {
+	var set RangeSet[E]
+
+	for _, e := range m.entries {
+		if n := len(set); n > 0 && set[n-1].High == e.Low {
+			set[n-1].High = e.High
+			continue
+		}
+
+		set = append(set, e.Range)
+	}
+
+	return set
+}
+
+// Lookup returns a RangeSet containing every element that maps to v.
+func (m RangeMap[E, V]) Lookup(v V) RangeSet[E] {
+	var set RangeSet[E]
+
+	for _, e := range m.entries {
+		if e.Value == v {
+			set = append(set, e.Range)
+		}
+	}
+
+	return set
+}
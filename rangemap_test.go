@@ -0,0 +1,118 @@
+package rangeset_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func dumpRangeMap[E Elem, V comparable](m RangeMap[E, V]) string {
+	var b strings.Builder
+
+	for r, v := range m.All() {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+
+		fmt.Fprintf(&b, "[%v,%v)=%v", r.Low, r.High, v)
+	}
+
+	return b.String()
+}
+
+func TestRangeMap(t *testing.T) {
+	type E int
+
+	set := func(lo, hi E, v string) func(*RangeMap[E, string]) {
+		return func(m *RangeMap[E, string]) { m.Set(lo, hi, v) }
+	}
+	del := func(lo, hi E) func(*RangeMap[E, string]) {
+		return func(m *RangeMap[E, string]) { m.Delete(lo, hi) }
+	}
+
+	testCases := []struct {
+		Ops      []func(*RangeMap[E, string])
+		Expected string
+	}{
+		{nil, ""},
+		{[]func(*RangeMap[E, string]){set(1, 5, "a")}, "[1,5)=a"},
+		{[]func(*RangeMap[E, string]){set(5, 1, "a")}, ""},
+		{[]func(*RangeMap[E, string]){set(1, 5, "a"), set(7, 9, "b")}, "[1,5)=a [7,9)=b"},
+		{[]func(*RangeMap[E, string]){set(1, 5, "a"), set(3, 9, "b")}, "[1,3)=a [3,9)=b"},
+		{[]func(*RangeMap[E, string]){set(1, 9, "a"), set(3, 5, "b")}, "[1,3)=a [3,5)=b [5,9)=a"},
+		{[]func(*RangeMap[E, string]){set(1, 9, "a"), set(3, 5, "a")}, "[1,9)=a"},
+		{[]func(*RangeMap[E, string]){set(1, 3, "a"), set(5, 7, "a"), set(3, 5, "a")}, "[1,7)=a"},
+		{[]func(*RangeMap[E, string]){set(1, 3, "a"), set(5, 7, "a"), set(3, 5, "b")}, "[1,3)=a [3,5)=b [5,7)=a"},
+		{[]func(*RangeMap[E, string]){set(1, 3, "a"), set(3, 5, "b"), set(5, 7, "c"), set(2, 6, "a")}, "[1,6)=a [6,7)=c"},
+		{[]func(*RangeMap[E, string]){set(1, 3, "a"), set(3, 5, "b"), set(5, 7, "c"), set(0, 8, "d")}, "[0,8)=d"},
+		{[]func(*RangeMap[E, string]){set(1, 9, "a"), del(3, 5)}, "[1,3)=a [5,9)=a"},
+		{[]func(*RangeMap[E, string]){set(1, 3, "a"), set(3, 5, "b"), del(2, 4)}, "[1,2)=a [4,5)=b"},
+		{[]func(*RangeMap[E, string]){set(1, 3, "a"), set(5, 7, "b"), del(0, 10)}, ""},
+		{[]func(*RangeMap[E, string]){set(1, 3, "a"), del(3, 5)}, "[1,3)=a"},
+		{[]func(*RangeMap[E, string]){set(1, 3, "a"), set(3, 5, "b"), set(3, 5, "a")}, "[1,5)=a"},
+	}
+
+	for i, c := range testCases {
+		var m RangeMap[E, string]
+
+		for _, op := range c.Ops {
+			op(&m)
+		}
+
+		if s := dumpRangeMap(m); s != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %q, but got %q", i, c.Expected, s)
+		}
+	}
+}
+
+func TestRangeMap_Get(t *testing.T) {
+	type E uint
+
+	var m RangeMap[E, int]
+
+	m.Set(1, 5, 10)
+	m.Set(5, 8, 20)
+	m.Set(10, 12, 10)
+
+	type result struct {
+		V  int
+		OK bool
+	}
+
+	wrap := func(v int, ok bool) result { return result{v, ok} }
+
+	testCases := []struct {
+		Result, Expected result
+	}{
+		{wrap(m.Get(0)), result{0, false}},
+		{wrap(m.Get(1)), result{10, true}},
+		{wrap(m.Get(4)), result{10, true}},
+		{wrap(m.Get(5)), result{20, true}},
+		{wrap(m.Get(8)), result{0, false}},
+		{wrap(m.Get(11)), result{10, true}},
+	}
+
+	for i, c := range testCases {
+		if c.Result != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+
+	assertions := []bool{
+		m.Len() == 3,
+		m.Keys().Equal(RangeSet[E]{{1, 8}, {10, 12}}),
+		m.Lookup(10).Equal(RangeSet[E]{{1, 5}, {10, 12}}),
+		m.Lookup(30).Equal(RangeSet[E]{}),
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
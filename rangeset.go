@@ -22,7 +22,7 @@ type Range[E Elem] struct {
 // The zero value for a RangeSet, i.e. a nil RangeSet, is an empty set.
 //
 // Since Range is half-open, you can never add the maximum value of E into
-// a RangeSet.
+// a RangeSet. Use ClosedRangeSet if you need to.
 type RangeSet[E Elem] []Range[E]
 
 // FromRange creates a RangeSet from range [lo, hi).
@@ -43,6 +43,9 @@ func Universal[E Elem]() RangeSet[E] {
 }
 
 // Add adds a single element into set.
+//
+// If v is the maximum value of E, which a RangeSet cannot hold, Add does
+// nothing.
 func (set *RangeSet[E]) Add(v E) {
 	set.AddRange(v, v+1)
 }
@@ -198,6 +201,8 @@ func (set *RangeSet[E]) DeleteRange(lo, hi E) {
 }
 
 // Contains reports whether set contains a single element.
+//
+// Contains always reports false for the maximum value of E.
 func (set RangeSet[E]) Contains(v E) bool {
 	return set.ContainsRange(v, v+1)
 }
@@ -211,7 +216,65 @@ func (set RangeSet[E]) ContainsRange(lo, hi E) bool {
 // Difference returns the subset of set that having all elements in other
 // excluded.
 func (set RangeSet[E]) Difference(other RangeSet[E]) RangeSet[E] {
-	return set.Intersection(other.Complement())
+	return appendDifference(nil, set, other)
+}
+
+// AppendDifference appends the subset of s1 that having all elements in s2
+// excluded to dst and returns the extended buffer.
+//
+// dst must not share backing storage with s1 or s2, and the result is
+// a valid RangeSet only if dst is empty or ends before s1 begins.
+func AppendDifference[E Elem](dst, s1, s2 RangeSet[E]) RangeSet[E] {
+	return appendDifference(dst, s1, s2)
+}
+
+// DifferenceWith removes every element in other from set.
+//
+// DifferenceWith reuses the backing storage of set if its capacity is at
+// least len(set)+len(other).
+func (set *RangeSet[E]) DifferenceWith(other RangeSet[E]) {
+	set.inPlace(other, appendDifference[E])
+}
+
+// appendDifference appends the subset of s1 that having all elements in s2
+// excluded to dst.
+//
+// appendDifference never writes past the Ranges it has already read, which
+// makes it usable by inPlace.
+func appendDifference[E Elem](dst, s1, s2 RangeSet[E]) RangeSet[E] {
+	res := dst
+
+	for len(s1) > 0 {
+		if len(s2) == 0 {
+			return append(res, s1...)
+		}
+
+		r := s1[0]
+		s1 = s1[1:]
+
+		i := sort.Search(len(s2), func(i int) bool { return s2[i].High > r.Low })
+		s2 = s2[i:]
+
+		for len(s2) > 0 && s2[0].Low < r.High {
+			if s2[0].Low > r.Low {
+				res = append(res, Range[E]{r.Low, s2[0].Low})
+			}
+
+			if s2[0].High >= r.High {
+				r.Low = r.High // s2[0] may overlap s1[0] too, keep it.
+				break
+			}
+
+			r.Low = s2[0].High
+			s2 = s2[1:]
+		}
+
+		if r.Low < r.High {
+			res = append(res, r)
+		}
+	}
+
+	return res
 }
 
 // Equal reports whether set is identical to other.
@@ -255,11 +318,14 @@ func (set RangeSet[E]) IsSubsetOf(other RangeSet[E]) bool {
 }
 
 // Count returns the number of element in set.
+//
+// Count never overflows: since a RangeSet cannot hold the maximum value of
+// E, it has at most 2^64-1 elements even if E is a 64-bit type.
 func (set RangeSet[E]) Count() uint64 {
 	var count uint64
 
 	for _, r := range set {
-		count += uint64(r.High - r.Low)
+		count += rangeSize(r)
 	}
 
 	return count
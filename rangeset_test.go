@@ -271,6 +271,11 @@ func TestCount(t *testing.T) {
 		RangeSet[E]{}.Count() == 0,
 		RangeSet[E]{{1, 4}}.Count() == 3,
 		RangeSet[E]{{1, 3}, {5, 7}}.Count() == 4,
+		Universal[E]().Count() == math.MaxUint64,
+		RangeSet[int8]{{-100, 100}}.Count() == 200,
+		Universal[int8]().Count() == math.MaxUint8,
+		Universal[uint64]().Count() == math.MaxUint64,
+		RangeSet[int64]{{math.MinInt64, 0}, {1, math.MaxInt64}}.Count() == math.MaxUint64-1,
 	}
 
 	for i, ok := range assertions {
@@ -0,0 +1,98 @@
+package rangeset
+
+import "sort"
+
+// Rank returns the number of elements in set that are less than v.
+//
+// Rank takes O(n) time. For repeated queries on a set that does not change,
+// use a RankIndex instead.
+func (set RangeSet[E]) Rank(v E) uint64 {
+	var rank uint64
+
+	for _, r := range set {
+		if r.Low >= v {
+			break
+		}
+
+		if r.High > v {
+			return rank + rangeSize(Range[E]{r.Low, v})
+		}
+
+		rank += rangeSize(r)
+	}
+
+	return rank
+}
+
+// Select returns the n-th smallest element in set, counting from zero.
+//
+// If n >= set.Count(), Select returns the zero value and false.
+//
+// Select takes O(n) time. For repeated queries on a set that does not
+// change, use a RankIndex instead.
+func (set RangeSet[E]) Select(n uint64) (E, bool) {
+	for _, r := range set {
+		if c := rangeSize(r); n >= c {
+			n -= c
+			continue
+		}
+
+		return r.Low + E(n), true
+	}
+
+	return 0, false
+}
+
+// A RankIndex answers Rank and Select queries on a RangeSet in O(log n)
+// time, at the cost of O(n) memory.
+//
+// A RankIndex holds a reference to the RangeSet it was built from. Changing
+// that RangeSet afterwards invalidates the RankIndex.
+type RankIndex[E Elem] struct {
+	set    RangeSet[E]
+	prefix []uint64 // prefix[i] is the number of elements in set[:i].
+}
+
+// NewRankIndex builds a RankIndex for set.
+func NewRankIndex[E Elem](set RangeSet[E]) *RankIndex[E] {
+	prefix := make([]uint64, len(set)+1)
+
+	for i, r := range set {
+		prefix[i+1] = prefix[i] + rangeSize(r)
+	}
+
+	return &RankIndex[E]{set, prefix}
+}
+
+// Count returns the number of elements in the indexed set.
+func (x *RankIndex[E]) Count() uint64 {
+	return x.prefix[len(x.set)]
+}
+
+// Rank returns the number of elements in the indexed set that are less
+// than v.
+func (x *RankIndex[E]) Rank(v E) uint64 {
+	s := x.set
+
+	i := sort.Search(len(s), func(i int) bool { return s[i].High > v })
+
+	if i < len(s) && s[i].Low < v {
+		return x.prefix[i] + rangeSize(Range[E]{s[i].Low, v})
+	}
+
+	return x.prefix[i]
+}
+
+// Select returns the n-th smallest element in the indexed set, counting
+// from zero.
+//
+// If n >= x.Count(), Select returns the zero value and false.
+func (x *RankIndex[E]) Select(n uint64) (E, bool) {
+	if n >= x.Count() {
+		return 0, false
+	}
+
+	i := sort.Search(len(x.set), func(i int) bool { return x.prefix[i+1] > n })
+
+	return x.set[i].Low + E(n-x.prefix[i]), true
+}
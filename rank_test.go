@@ -0,0 +1,78 @@
+package rangeset_test
+
+import (
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestRank(t *testing.T) {
+	type E int
+
+	s := RangeSet[E]{{1, 4}, {6, 8}, {10, 11}}
+	x := NewRankIndex(s)
+
+	testCases := []struct {
+		Input    E
+		Expected uint64
+	}{
+		{-5, 0},
+		{1, 0},
+		{2, 1},
+		{4, 3},
+		{5, 3},
+		{6, 3},
+		{7, 4},
+		{10, 5},
+		{11, 6},
+		{100, 6},
+	}
+
+	for i, c := range testCases {
+		if r := s.Rank(c.Input); r != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: RangeSet.Rank(%v): want %v, but got %v", i, c.Input, c.Expected, r)
+		}
+
+		if r := x.Rank(c.Input); r != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: RankIndex.Rank(%v): want %v, but got %v", i, c.Input, c.Expected, r)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	type E uint64
+
+	s := RangeSet[E]{{1, 4}, {6, 8}, {10, 11}}
+	x := NewRankIndex(s)
+
+	elems := []E{1, 2, 3, 6, 7, 10}
+
+	for n := uint64(0); n < 8; n++ {
+		want, wantOK := E(0), false
+		if n < uint64(len(elems)) {
+			want, wantOK = elems[n], true
+		}
+
+		if v, ok := s.Select(n); v != want || ok != wantOK {
+			t.Fail()
+			t.Logf("RangeSet.Select(%v): want (%v, %v), but got (%v, %v)", n, want, wantOK, v, ok)
+		}
+
+		if v, ok := x.Select(n); v != want || ok != wantOK {
+			t.Fail()
+			t.Logf("RankIndex.Select(%v): want (%v, %v), but got (%v, %v)", n, want, wantOK, v, ok)
+		}
+
+		if wantOK && x.Rank(want) != n {
+			t.Fail()
+			t.Logf("RankIndex.Rank(%v): want %v, but got %v", want, n, x.Rank(want))
+		}
+	}
+
+	if x.Count() != s.Count() {
+		t.Fail()
+		t.Logf("RankIndex.Count(): want %v, but got %v", s.Count(), x.Count())
+	}
+}
@@ -0,0 +1,134 @@
+package rangeset
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strconv"
+	"unicode"
+)
+
+// RegexpClass returns a regular expression character class, in the syntax
+// accepted by package regexp, that matches every rune in set, e.g. "[0-9_a-z]".
+//
+// Runes outside [0, unicode.MaxRune] are ignored. Whichever is shorter of
+// the class and its negated form (e.g. "[^\n]") is returned. Printable
+// runes are written as is, others are escaped.
+func RegexpClass(set RangeSet[rune]) string {
+	set = set.Intersection(FromRange[rune](0, unicode.MaxRune+1))
+
+	b := appendRegexpClass(nil, set, false)
+
+	if complement := set.Complement().Intersection(FromRange[rune](0, unicode.MaxRune+1)); len(complement) != 0 {
+		if neg := appendRegexpClass(nil, complement, true); len(neg) < len(b) {
+			b = neg
+		}
+	}
+
+	return string(b)
+}
+
+func appendRegexpClass(b []byte, set RangeSet[rune], negated bool) []byte {
+	b = append(b, '[')
+
+	if negated {
+		b = append(b, '^')
+	}
+
+	if len(set) == 0 {
+		// An empty class is not allowed; negate the whole range instead.
+		return append(b, `^\x00-\x{10ffff}]`...)
+	}
+
+	for _, r := range set {
+		b = appendRegexpRune(b, r.Low)
+
+		switch r.High - r.Low {
+		case 1:
+		case 2:
+			b = appendRegexpRune(b, r.Low+1)
+		default:
+			b = append(b, '-')
+			b = appendRegexpRune(b, r.High-1)
+		}
+	}
+
+	return append(b, ']')
+}
+
+func appendRegexpRune(b []byte, r rune) []byte {
+	switch r {
+	case '\\', '[', ']', '^', '-':
+		return append(b, '\\', byte(r))
+	case '\t':
+		return append(b, `\t`...)
+	case '\n':
+		return append(b, `\n`...)
+	case '\v':
+		return append(b, `\v`...)
+	case '\f':
+		return append(b, `\f`...)
+	case '\r':
+		return append(b, `\r`...)
+	}
+
+	if unicode.IsPrint(r) {
+		return append(b, string(r)...)
+	}
+
+	if r < 0x10 {
+		return append(b, `\x0`+strconv.FormatInt(int64(r), 16)...)
+	}
+
+	if r < 0x100 {
+		return append(b, `\x`+strconv.FormatInt(int64(r), 16)...)
+	}
+
+	return append(b, `\x{`+strconv.FormatInt(int64(r), 16)+`}`...)
+}
+
+// ParseRegexpClass parses a regular expression, in the syntax accepted by
+// package regexp, that matches a single rune, and returns the set of runes
+// it matches.
+//
+// Besides bracketed classes like "[a-z0-9_]" or "[^[:space:]]", anything
+// that matches a single rune is accepted, e.g. "x", ".", `\d` or `\pL`.
+// Negated classes and "." do match '\n'.
+func ParseRegexpClass(s string) (RangeSet[rune], error) {
+	re, err := syntax.Parse(s, syntax.Perl|syntax.DotNL)
+	if err != nil {
+		return nil, fmt.Errorf("rangeset: %w", err)
+	}
+
+	re = re.Simplify()
+
+	var set RangeSet[rune]
+
+	switch re.Op {
+	case syntax.OpNoMatch:
+	case syntax.OpAnyChar:
+		set = FromRange[rune](0, unicode.MaxRune+1)
+	case syntax.OpAnyCharNotNL:
+		set = RangeSet[rune]{{0, '\n'}, {'\n' + 1, unicode.MaxRune + 1}}
+	case syntax.OpCharClass:
+		for i := 0; i < len(re.Rune); i += 2 {
+			set.AddRange(re.Rune[i], re.Rune[i+1]+1)
+		}
+	case syntax.OpLiteral:
+		if len(re.Rune) != 1 {
+			return nil, fmt.Errorf("rangeset: regexp %q matches more than a single rune", s)
+		}
+
+		r := re.Rune[0]
+		set.Add(r)
+
+		if re.Flags&syntax.FoldCase != 0 {
+			for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+				set.Add(f)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("rangeset: regexp %q is not a character class", s)
+	}
+
+	return set, nil
+}
@@ -0,0 +1,119 @@
+package rangeset_test
+
+import (
+	"math/rand"
+	"regexp"
+	"testing"
+	"unicode"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestRegexpClass(t *testing.T) {
+	testCases := []struct {
+		Result, Expected string
+	}{
+		{RegexpClass(RangeSet[rune]{}), `[^\x00-\x{10ffff}]`},
+		{RegexpClass(RangeSet[rune]{{'0', '9' + 1}, {'_', '_' + 1}, {'a', 'z' + 1}}), `[0-9_a-z]`},
+		{RegexpClass(RangeSet[rune]{{'a', 'c'}, {'x', 'y'}}), `[abx]`},
+		{RegexpClass(RangeSet[rune]{{'-', '.'}, {'[', '_'}}), `[\-\[-\^]`},
+		{RegexpClass(RangeSet[rune]{{'\t', '\n' + 1}, {0x7f, 0x80}, {0x2028, 0x2029}}), `[\t\n\x7f\x{2028}]`},
+		{RegexpClass(RangeSet[rune]{{0, '\n'}, {'\n' + 1, unicode.MaxRune + 1}}), `[^\n]`},
+		{RegexpClass(RangeSet[rune]{{-5, 'a'}, {'b', unicode.MaxRune + 5}}), `[^a]`},
+		{RegexpClass(Universal[rune]()), `[\x00-\x{10ffff}]`},
+	}
+
+	for i, c := range testCases {
+		if c.Result != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %q, but got %q", i, c.Expected, c.Result)
+		}
+	}
+}
+
+func TestParseRegexpClass(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected RangeSet[rune]
+	}{
+		{`[a-z0-9_]`, RangeSet[rune]{{'0', '9' + 1}, {'_', '_' + 1}, {'a', 'z' + 1}}},
+		{`[^\n]`, RangeSet[rune]{{0, '\n'}, {'\n' + 1, unicode.MaxRune + 1}}},
+		{`[\]\-\\]`, RangeSet[rune]{{'-', '.'}, {'\\', '^'}}},
+		{`[[:digit:][:upper:]]`, RangeSet[rune]{{'0', '9' + 1}, {'A', 'Z' + 1}}},
+		{`[^[:space:]]`, RangeSet[rune]{{0, '\t'}, {'\r' + 1, ' '}, {' ' + 1, unicode.MaxRune + 1}}},
+		{`\d`, RangeSet[rune]{{'0', '9' + 1}}},
+		{`\PN`, FromRangeTable(unicode.N).Complement().Intersection(FromRange[rune](0, unicode.MaxRune+1))},
+		{`\x{10ffff}`, RangeSet[rune]{{unicode.MaxRune, unicode.MaxRune + 1}}},
+		{`(?i)k`, RangeSet[rune]{{'K', 'L'}, {'k', 'l'}, {'K', 'Å'}}},
+		{`.`, RangeSet[rune]{{0, unicode.MaxRune + 1}}},
+		{`[^\x00-\x{10ffff}]`, RangeSet[rune]{}},
+	}
+
+	for i, c := range testCases {
+		s, err := ParseRegexpClass(c.Input)
+		if err != nil {
+			t.Fail()
+			t.Logf("Case %v: unexpected error: %v", i, err)
+
+			continue
+		}
+
+		if !s.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, s)
+		}
+	}
+}
+
+func TestParseRegexpClass_error(t *testing.T) {
+	inputs := []string{`[a-`, `[z-a]`, `ab`, `a*`, `(a)`, `^`}
+
+	for i, input := range inputs {
+		if _, err := ParseRegexpClass(input); err == nil {
+			t.Fail()
+			t.Logf("Case %v: want an error for %q, but got nil", i, input)
+		}
+	}
+}
+
+func TestRegexpClass_roundTrip(t *testing.T) {
+	rand := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		var set RangeSet[rune]
+
+		for j := rand.Intn(10); j > 0; j-- {
+			lo := rand.Int31n(0x200)
+			if rand.Intn(4) == 0 {
+				lo = rand.Int31n(unicode.MaxRune + 1)
+			}
+
+			set.AddRange(lo, lo+rand.Int31n(5)+1)
+		}
+
+		if rand.Intn(2) == 0 {
+			set = set.Complement()
+		}
+
+		set = set.Intersection(FromRange[rune](0, unicode.MaxRune+1))
+
+		class := RegexpClass(set)
+
+		result, err := ParseRegexpClass(class)
+		if err != nil {
+			t.Fatalf("Case %v: parsing %q: %v", i, class, err)
+		}
+
+		if !result.Equal(set) {
+			t.Fatalf("Case %v: %q: want %v, but got %v", i, class, set, result)
+		}
+
+		re := regexp.MustCompile(`^` + class + `$`)
+
+		for r := rune(0); r < 0x200; r++ {
+			if re.MatchString(string(r)) != set.Contains(r) {
+				t.Fatalf("Case %v: %q: %U", i, class, r)
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package rangeset
+
+import "container/heap"
+
+// Difference returns the subset of base that having all elements in any of
+// subtracts excluded.
+func Difference[E Elem](base RangeSet[E], subtracts ...RangeSet[E]) RangeSet[E] {
+	if len(subtracts) == 0 || len(base) == 0 {
+		// Always return a distinct set (unless it's nil).
+		return append(RangeSet[E](nil), base...)
+	}
+
+	sets := append([]RangeSet[E]{base}, subtracts...)
+
+	return sweep(sets, func(n int, inFirst bool) bool { return inFirst && n == 1 })
+}
+
+// Threshold returns a RangeSet containing every element that is present in
+// at least k of sets.
+//
+// If k <= 0, Threshold returns the return value of Universal[E]().
+func Threshold[E Elem](k int, sets ...RangeSet[E]) RangeSet[E] {
+	if k <= 0 {
+		return Universal[E]()
+	}
+
+	if k > len(sets) {
+		return nil
+	}
+
+	return sweep(sets, func(n int, _ bool) bool { return n >= k })
+}
+
+// sweep walks through endpoints of Ranges in sets in ascending order, and
+// returns a RangeSet containing every element for which in reports true.
+// in is given the number of sets containing the element, and whether
+// sets[0] is one of them. in must report false when n is zero.
+//
+// sweep takes O(N log k) time, where N is the total number of Ranges and
+// k the number of sets.
+func sweep[E Elem](sets []RangeSet[E], in func(n int, inFirst bool) bool) RangeSet[E] {
+	h := make(sweepHeap[E], 0, len(sets))
+
+	for i, s := range sets {
+		if len(s) > 0 {
+			h = append(h, sweepCursor[E]{s, 0, i})
+		}
+	}
+
+	heap.Init(&h)
+
+	var (
+		res     RangeSet[E]
+		lo      E
+		n       int
+		inFirst bool
+		inside  bool
+	)
+
+	for len(h) > 0 {
+		pos := h[0].pos()
+
+		// Process every endpoint at pos before evaluating in.
+		for len(h) > 0 && h[0].pos() == pos {
+			c := &h[0]
+
+			if c.isLow() {
+				n++
+			} else {
+				n--
+			}
+
+			if c.id == 0 {
+				inFirst = c.isLow()
+			}
+
+			if c.i++; c.i < 2*len(c.set) {
+				heap.Fix(&h, 0)
+			} else {
+				heap.Pop(&h)
+			}
+		}
+
+		now := in(n, inFirst)
+
+		switch {
+		case now && !inside:
+			lo = pos
+		case !now && inside:
+			res = append(res, Range[E]{lo, pos})
+		}
+
+		inside = now
+	}
+
+	return res
+}
+
+// A sweepCursor walks through endpoints of Ranges in a RangeSet.
+type sweepCursor[E Elem] struct {
+	set RangeSet[E]
+	i   int // Index of the current endpoint; Low of set[i/2] if i is even.
+	id  int // Index of set in the sets being swept.
+}
+
+func (c *sweepCursor[E]) isLow() bool {
+	return c.i%2 == 0
+}
+
+func (c *sweepCursor[E]) pos() E {
+	r := c.set[c.i/2]
+
+	if c.isLow() {
+		return r.Low
+	}
+
+	return r.High
+}
+
+// sweepHeap is a min-heap of sweepCursors ordered by their current
+// endpoints.
+type sweepHeap[E Elem] []sweepCursor[E]
+
+func (h sweepHeap[E]) Len() int           { return len(h) }
+func (h sweepHeap[E]) Less(i, j int) bool { return h[i].pos() < h[j].pos() }
+func (h sweepHeap[E]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *sweepHeap[E]) Push(x any)        { *h = append(*h, x.(sweepCursor[E])) }
+
+func (h *sweepHeap[E]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,116 @@
+package rangeset_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestDifference_variadic(t *testing.T) {
+	type E int
+
+	testCases := []struct {
+		Result, Expected RangeSet[E]
+	}{
+		{Difference(RangeSet[E]{{1, 20}}), RangeSet[E]{{1, 20}}},
+		{Difference(RangeSet[E]{}, RangeSet[E]{{1, 20}}), RangeSet[E]{}},
+		{Difference(RangeSet[E]{{1, 20}}, RangeSet[E]{{3, 5}}), RangeSet[E]{{1, 3}, {5, 20}}},
+		{
+			Difference(
+				RangeSet[E]{{1, 20}, {30, 40}},
+				RangeSet[E]{{3, 5}, {35, 50}},
+				RangeSet[E]{{4, 8}, {19, 31}},
+			),
+			RangeSet[E]{{1, 3}, {8, 19}, {31, 35}},
+		},
+	}
+
+	for i, c := range testCases {
+		if !c.Result.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	type E int
+
+	a := RangeSet[E]{{1, 10}}
+	b := RangeSet[E]{{5, 15}}
+	c := RangeSet[E]{{8, 20}, {25, 30}}
+
+	testCases := []struct {
+		Result, Expected RangeSet[E]
+	}{
+		{Threshold(1, a, b, c), RangeSet[E]{{1, 20}, {25, 30}}},
+		{Threshold(2, a, b, c), RangeSet[E]{{5, 15}}},
+		{Threshold(3, a, b, c), RangeSet[E]{{8, 10}}},
+		{Threshold(4, a, b, c), RangeSet[E]{}},
+		{Threshold(0, a, b, c), Universal[E]()},
+		{Threshold[E](1), RangeSet[E]{}},
+	}
+
+	for i, c := range testCases {
+		if !c.Result.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
+
+func TestSweep_random(t *testing.T) {
+	type E uint8
+
+	rng := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 200; n++ {
+		sets := make([]RangeSet[E], 1+rng.Intn(6))
+
+		for i := range sets {
+			for j := 0; j < 5; j++ {
+				lo := E(rng.Intn(200))
+				sets[i].AddRange(lo, lo+E(rng.Intn(30)))
+			}
+		}
+
+		k := 1 + rng.Intn(len(sets))
+
+		var diff, odd, atLeastK RangeSet[E]
+
+		for v := E(0); v < 255; v++ {
+			count := 0
+
+			for _, s := range sets {
+				if s.Contains(v) {
+					count++
+				}
+			}
+
+			if sets[0].Contains(v) && count == 1 {
+				diff.Add(v)
+			}
+
+			if count%2 == 1 {
+				odd.Add(v)
+			}
+
+			if count >= k {
+				atLeastK.Add(v)
+			}
+		}
+
+		if result := Difference(sets[0], sets[1:]...); !result.Equal(diff) {
+			t.Fatalf("Difference(%v): want %v, but got %v", sets, diff, result)
+		}
+
+		if result := SymmetricDifference(sets...); !result.Equal(odd) {
+			t.Fatalf("SymmetricDifference(%v): want %v, but got %v", sets, odd, result)
+		}
+
+		if result := Threshold(k, sets...); !result.Equal(atLeastK) {
+			t.Fatalf("Threshold(%v, %v): want %v, but got %v", k, sets, atLeastK, result)
+		}
+	}
+}
@@ -1,9 +1,64 @@
 package rangeset
 
-import "sort"
+import (
+	"slices"
+	"sort"
+)
+
+// SymmetricDifference returns the symmetric difference of zero or more sets,
+// i.e. a RangeSet containing every element that is present in an odd number
+// of sets.
+func SymmetricDifference[E Elem](sets ...RangeSet[E]) RangeSet[E] {
+	switch len(sets) {
+	case 0:
+		return nil
+	case 1:
+		// Always return a distinct set (unless it's nil).
+		return append(RangeSet[E](nil), sets[0]...)
+	case 2:
+		return symmetricDifference(sets[0], sets[1])
+	}
+
+	return sweep(sets, func(n int, _ bool) bool { return n%2 == 1 })
+}
+
+// AppendSymmetricDifference appends the symmetric difference of s1 and s2
+// to dst and returns the extended buffer.
+//
+// dst must not share backing storage with s1 or s2, and the result is
+// a valid RangeSet only if dst is empty or ends before s1 and s2 begin.
+func AppendSymmetricDifference[E Elem](dst, s1, s2 RangeSet[E]) RangeSet[E] {
+	if len(s1) < len(s2) {
+		s1, s2 = s2, s1
+	}
+
+	res := append(dst, s1...)
+	set := res[len(dst):]
+
+	for _, r := range s2 {
+		symmetricDifferenceRange(&set, r.Low, r.High)
+	}
+
+	return append(res[:len(dst)], set...)
+}
+
+// SymmetricDifferenceWith sets set to the symmetric difference of set and
+// other.
+//
+// SymmetricDifferenceWith works on the backing storage of set directly,
+// growing it only when needed.
+func (set *RangeSet[E]) SymmetricDifferenceWith(other RangeSet[E]) {
+	if sharesStorage(*set, other) {
+		other = slices.Clone(other)
+	}
+
+	for _, r := range other {
+		symmetricDifferenceRange(set, r.Low, r.High)
+	}
+}
 
-// SymmetricDifference returns the symmetric difference of two sets.
-func SymmetricDifference[E Elem](s1, s2 RangeSet[E]) RangeSet[E] {
+// symmetricDifference returns the symmetric difference of two sets.
+func symmetricDifference[E Elem](s1, s2 RangeSet[E]) RangeSet[E] {
 	if len(s1) < len(s2) {
 		s1, s2 = s2, s1
 	}
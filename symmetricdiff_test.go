@@ -98,6 +98,16 @@ func TestSymmetricDifference(t *testing.T) {
 			SymmetricDifference(RangeSet[E]{}, RangeSet[E]{}),
 			RangeSet[E]{},
 		},
+		{
+			SymmetricDifference(
+				RangeSet[E]{{1, 10}},
+				RangeSet[E]{{5, 15}},
+				RangeSet[E]{{8, 20}},
+			),
+			RangeSet[E]{{1, 5}, {8, 10}, {15, 20}},
+		},
+		{SymmetricDifference[E](), RangeSet[E]{}},
+		{SymmetricDifference(RangeSet[E]{{1, 3}}), RangeSet[E]{{1, 3}}},
 	}
 
 	for i, c := range testCases {
@@ -0,0 +1,92 @@
+package rangeset
+
+import "sync"
+
+// A SyncRangeSet is a RangeSet that is safe for concurrent use by multiple
+// goroutines. Readers do not block each other.
+//
+// The zero value for a SyncRangeSet is an empty set. A SyncRangeSet must
+// not be copied after first use.
+type SyncRangeSet[E Elem] struct {
+	mu  sync.RWMutex
+	set RangeSet[E]
+}
+
+// NewSyncRangeSet creates a SyncRangeSet containing a copy of set.
+func NewSyncRangeSet[E Elem](set RangeSet[E]) *SyncRangeSet[E] {
+	return &SyncRangeSet[E]{set: append(RangeSet[E](nil), set...)}
+}
+
+// Add adds a single element into s.
+func (s *SyncRangeSet[E]) Add(v E) {
+	s.AddRange(v, v+1)
+}
+
+// AddRange adds range [lo, hi) into s.
+func (s *SyncRangeSet[E]) AddRange(lo, hi E) {
+	s.mu.Lock()
+	s.set.AddRange(lo, hi)
+	s.mu.Unlock()
+}
+
+// Delete removes a single element from s.
+func (s *SyncRangeSet[E]) Delete(v E) {
+	s.DeleteRange(v, v+1)
+}
+
+// DeleteRange removes range [lo, hi) from s.
+func (s *SyncRangeSet[E]) DeleteRange(lo, hi E) {
+	s.mu.Lock()
+	s.set.DeleteRange(lo, hi)
+	s.mu.Unlock()
+}
+
+// TestAndAddRange atomically adds range [lo, hi) into s if s contains none
+// of the elements in range [lo, hi), and reports whether it did.
+//
+// If lo >= hi, TestAndAddRange does nothing and reports false.
+func (s *SyncRangeSet[E]) TestAndAddRange(lo, hi E) bool {
+	if lo >= hi {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.set.Overlaps(RangeSet[E]{{lo, hi}}) {
+		return false
+	}
+
+	s.set.AddRange(lo, hi)
+
+	return true
+}
+
+// Contains reports whether s contains a single element.
+func (s *SyncRangeSet[E]) Contains(v E) bool {
+	return s.ContainsRange(v, v+1)
+}
+
+// ContainsRange reports whether s contains every element in range [lo, hi).
+func (s *SyncRangeSet[E]) ContainsRange(lo, hi E) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.set.ContainsRange(lo, hi)
+}
+
+// Count returns the number of element in s.
+func (s *SyncRangeSet[E]) Count() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.set.Count()
+}
+
+// Snapshot returns a copy of the RangeSet that s currently holds.
+func (s *SyncRangeSet[E]) Snapshot() RangeSet[E] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append(RangeSet[E](nil), s.set...)
+}
@@ -0,0 +1,111 @@
+package rangeset_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestSyncRangeSet(t *testing.T) {
+	type E int
+
+	s := NewSyncRangeSet(RangeSet[E]{{1, 5}})
+
+	s.Add(5)
+	s.AddRange(10, 20)
+	s.Delete(2)
+	s.DeleteRange(15, 25)
+
+	assertions := []bool{
+		s.Contains(1),
+		!s.Contains(2),
+		s.ContainsRange(3, 6),
+		s.Count() == 9,
+		s.Snapshot().Equal(RangeSet[E]{{1, 2}, {3, 6}, {10, 15}}),
+		!s.TestAndAddRange(0, 2),
+		!s.TestAndAddRange(4, 4),
+		s.TestAndAddRange(6, 10),
+		s.Snapshot().Equal(RangeSet[E]{{1, 2}, {3, 15}}),
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
+
+func TestSyncRangeSet_concurrent(t *testing.T) {
+	type E uint32
+
+	const (
+		goroutines = 8
+		perRoutine = 500
+	)
+
+	var (
+		s  SyncRangeSet[E]
+		wg sync.WaitGroup
+	)
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+
+		go func(g int) {
+			defer wg.Done()
+
+			for i := 0; i < perRoutine; i++ {
+				v := E(g*perRoutine + i)
+				s.Add(v)
+
+				if !s.Contains(v) {
+					t.Errorf("element %v missing right after Add", v)
+					return
+				}
+
+				_ = s.Snapshot()
+			}
+		}(g)
+	}
+
+	wg.Wait()
+
+	if expected := (RangeSet[E]{{0, goroutines * perRoutine}}); !s.Snapshot().Equal(expected) {
+		t.Fatalf("want %v, but got %v", expected, s.Snapshot())
+	}
+}
+
+func TestSyncRangeSet_TestAndAddRange(t *testing.T) {
+	type E int
+
+	const slots = 100
+
+	var (
+		s       SyncRangeSet[E]
+		wg      sync.WaitGroup
+		claimed atomic.Int64
+	)
+
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for v := E(0); v < slots; v++ {
+				if s.TestAndAddRange(v, v+1) {
+					claimed.Add(1)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if n := claimed.Load(); n != slots {
+		t.Fatalf("want %v successful claims, but got %v", slots, n)
+	}
+}
@@ -0,0 +1,263 @@
+package rangeset
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A Notation specifies how a RangeSet is written as text.
+type Notation int
+
+const (
+	// DashNotation writes each Range as an inclusive "lo-hi" pair, or just
+	// "lo" if the Range contains a single element, e.g. "1-5,7,9-12".
+	DashNotation Notation = iota
+
+	// HalfOpenNotation writes each Range as a half-open interval "[lo,hi)",
+	// e.g. "[1,6),[7,8),[9,13)".
+	HalfOpenNotation
+)
+
+// String returns the text form of set in DashNotation.
+func (set RangeSet[E]) String() string {
+	return set.Format(DashNotation)
+}
+
+// Format returns the text form of set in notation n.
+func (set RangeSet[E]) Format(n Notation) string {
+	return string(set.AppendFormat(nil, n))
+}
+
+// AppendFormat appends the text form of set in notation n to b and returns
+// the extended buffer.
+func (set RangeSet[E]) AppendFormat(b []byte, n Notation) []byte {
+	for i, r := range set {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		switch n {
+		case HalfOpenNotation:
+			b = appendRange(b, r)
+		default:
+			b = appendElem(b, r.Low)
+
+			if r.High-r.Low != 1 {
+				b = append(b, '-')
+				b = appendElem(b, r.High-1)
+			}
+		}
+	}
+
+	return b
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The text form is in DashNotation.
+func (set RangeSet[E]) MarshalText() ([]byte, error) {
+	return set.AppendFormat(nil, DashNotation), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// See Parse for accepted text forms.
+func (set *RangeSet[E]) UnmarshalText(text []byte) error {
+	s, err := Parse[E](string(text))
+	if err != nil {
+		return err
+	}
+
+	*set = s
+
+	return nil
+}
+
+// Parse parses a comma-separated list of ranges into a RangeSet.
+//
+// Each item can be written in any of the following forms:
+//
+//	7        a single element
+//	1-5      an inclusive range, both 1 and 5 included
+//	[1,6)    a half-open range, 1 included, 6 excluded
+//	[1,5]    a closed range, both 1 and 5 included
+//
+// Items may appear in any order and may overlap; the result is sorted and
+// merged. Whitespace around items and numbers is ignored. An empty or
+// all-whitespace string yields an empty set; otherwise, every item must be
+// non-empty, including the last one.
+func Parse[E Elem](s string) (RangeSet[E], error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var res RangeSet[E]
+
+	for more := true; more; {
+		var item string
+
+		item, s, more = nextItem(s)
+		item = strings.TrimSpace(item)
+
+		r, err := parseRange[E](item)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, r)
+	}
+
+	return normalize(res), nil
+}
+
+// nextItem splits off the first top-level comma-separated item from s,
+// and reports whether a comma follows it.
+func nextItem(s string) (item, rest string, more bool) {
+	inBracket := false
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			inBracket = true
+		case ')', ']':
+			inBracket = false
+		case ',':
+			if !inBracket {
+				return s[:i], s[i+1:], true
+			}
+		}
+	}
+
+	return s, "", false
+}
+
+var (
+	errEmptyItem    = errors.New("empty item")
+	errReversed     = errors.New("low is greater than high")
+	errUnbalanced   = errors.New("unbalanced brackets")
+	errOutOfDomain  = errors.New("range exceeds the maximum value")
+	errMissingComma = errors.New("missing comma in bracketed range")
+)
+
+func parseRange[E Elem](item string) (r Range[E], err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("rangeset: parsing %q: %w", item, err)
+		}
+	}()
+
+	if item == "" {
+		return r, errEmptyItem
+	}
+
+	if item[0] == '[' {
+		return parseBracketRange[E](item)
+	}
+
+	lo, hi := item, item
+
+	for i := 1; i < len(item); i++ {
+		if item[i] == '-' {
+			if p := strings.TrimSpace(item[:i]); p != "" && isDigit(p[len(p)-1]) {
+				lo, hi = p, item[i+1:]
+				break
+			}
+		}
+	}
+
+	if r.Low, err = parseElem[E](lo); err != nil {
+		return
+	}
+
+	if r.High, err = parseElem[E](hi); err != nil {
+		return
+	}
+
+	return inclusiveRange(r.Low, r.High)
+}
+
+func parseBracketRange[E Elem](item string) (r Range[E], err error) {
+	closing := item[len(item)-1]
+	if len(item) < 2 || (closing != ')' && closing != ']') {
+		return r, errUnbalanced
+	}
+
+	inner := item[1 : len(item)-1]
+
+	i := strings.IndexByte(inner, ',')
+	if i < 0 {
+		return r, errMissingComma
+	}
+
+	if r.Low, err = parseElem[E](inner[:i]); err != nil {
+		return
+	}
+
+	if r.High, err = parseElem[E](inner[i+1:]); err != nil {
+		return
+	}
+
+	if closing == ']' {
+		return inclusiveRange(r.Low, r.High)
+	}
+
+	if r.Low > r.High {
+		return r, errReversed
+	}
+
+	return r, nil
+}
+
+// inclusiveRange returns the half-open equivalent of closed range [lo, hi].
+func inclusiveRange[E Elem](lo, hi E) (Range[E], error) {
+	if lo > hi {
+		return Range[E]{}, errReversed
+	}
+
+	if hi == maxOf[E]() {
+		return Range[E]{}, errOutOfDomain
+	}
+
+	return Range[E]{lo, hi + 1}, nil
+}
+
+func parseElem[E Elem](s string) (E, error) {
+	s = strings.TrimSpace(s)
+
+	if isSigned[E]() {
+		v, err := strconv.ParseInt(s, 10, bitSize[E]())
+		if err != nil {
+			return 0, err.(*strconv.NumError).Err
+		}
+
+		return E(v), nil
+	}
+
+	v, err := strconv.ParseUint(s, 10, bitSize[E]())
+	if err != nil {
+		return 0, err.(*strconv.NumError).Err
+	}
+
+	return E(v), nil
+}
+
+func appendElem[E Elem](b []byte, v E) []byte {
+	if isSigned[E]() {
+		return strconv.AppendInt(b, int64(v), 10)
+	}
+
+	return strconv.AppendUint(b, uint64(v), 10)
+}
+
+// appendRange appends r in the form "[lo,hi)" to b.
+func appendRange[E Elem](b []byte, r Range[E]) []byte {
+	b = append(b, '[')
+	b = appendElem(b, r.Low)
+	b = append(b, ',')
+	b = appendElem(b, r.High)
+	return append(b, ')')
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,111 @@
+package rangeset_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestFormat(t *testing.T) {
+	type E int
+
+	testCases := []struct {
+		Result, Expected string
+	}{
+		{RangeSet[E]{}.String(), ""},
+		{RangeSet[E]{{1, 6}, {7, 8}, {9, 13}}.String(), "1-5,7,9-12"},
+		{RangeSet[E]{{-5, -2}, {0, 1}}.String(), "-5--3,0"},
+		{RangeSet[E]{{1, 6}, {7, 8}}.Format(HalfOpenNotation), "[1,6),[7,8)"},
+		{RangeSet[uint8]{{250, math.MaxUint8}}.String(), "250-254"},
+	}
+
+	for i, c := range testCases {
+		if c.Result != c.Expected {
+			t.Fail()
+			t.Logf("Case %v: want %q, but got %q", i, c.Expected, c.Result)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	type E int
+
+	testCases := []struct {
+		Input    string
+		Expected RangeSet[E]
+	}{
+		{"", RangeSet[E]{}},
+		{" \t", RangeSet[E]{}},
+		{"1-5,7,9-12", RangeSet[E]{{1, 6}, {7, 8}, {9, 13}}},
+		{" 9 - 12 , 7 ,1-5 ", RangeSet[E]{{1, 6}, {7, 8}, {9, 13}}},
+		{"[1,6),[7,8)", RangeSet[E]{{1, 6}, {7, 8}}},
+		{"[1, 5], 6", RangeSet[E]{{1, 7}}},
+		{"3-8,1-4,10,9", RangeSet[E]{{1, 11}}},
+		{"-5--3,-1", RangeSet[E]{{-5, -2}, {-1, 0}}},
+		{"[4,4)", RangeSet[E]{}},
+	}
+
+	for i, c := range testCases {
+		s, err := Parse[E](c.Input)
+		if err != nil {
+			t.Fail()
+			t.Logf("Case %v: unexpected error: %v", i, err)
+
+			continue
+		}
+
+		if !s.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, s)
+		}
+	}
+}
+
+func TestParse_error(t *testing.T) {
+	type E int8
+
+	inputs := []string{
+		"1,,2",
+		"1,",
+		"1, ",
+		",1",
+		",",
+		"5-1",
+		"[5,1)",
+		"[1,5",
+		"[1;5)",
+		"x",
+		"1-128",
+		"1-127",
+	}
+
+	for i, input := range inputs {
+		if _, err := Parse[E](input); err == nil {
+			t.Fail()
+			t.Logf("Case %v: want an error for %q, but got nil", i, input)
+		}
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	type E uint16
+
+	s := RangeSet[E]{{1, 6}, {7, 8}, {9, 13}}
+
+	text, err := s.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var r RangeSet[E]
+
+	if err := r.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+
+	if !r.Equal(s) {
+		t.Fail()
+		t.Logf("want %v, but got %v", s, r)
+	}
+}
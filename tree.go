@@ -0,0 +1,399 @@
+package rangeset
+
+import "iter"
+
+// A TreeRangeSet is a set of discrete Ranges kept in a balanced binary
+// search tree.
+//
+// Unlike RangeSet, whose AddRange and DeleteRange take O(n) time to shift
+// the backing slice, TreeRangeSet does both in O(log n) time (plus O(log n)
+// for each Range merged or removed), which suits huge, fragmented sets.
+//
+// Tree nodes are never modified once created, so copying a TreeRangeSet is
+// an O(1) operation and the copy is not affected by later changes made to
+// the original, and vice versa.
+//
+// The zero value for a TreeRangeSet is an empty set.
+type TreeRangeSet[E Elem] struct {
+	root *treeNode[E]
+}
+
+// NewTreeRangeSet creates a TreeRangeSet containing the same elements as set.
+func NewTreeRangeSet[E Elem](set RangeSet[E]) TreeRangeSet[E] {
+	return TreeRangeSet[E]{buildTree(set)}
+}
+
+// RangeSet returns a RangeSet containing the same elements as t.
+func (t TreeRangeSet[E]) RangeSet() RangeSet[E] {
+	if t.root == nil {
+		return nil
+	}
+
+	set := make(RangeSet[E], 0, t.root.size)
+
+	for r := range t.Ranges() {
+		set = append(set, r)
+	}
+
+	return set
+}
+
+// Add adds a single element into t.
+func (t *TreeRangeSet[E]) Add(v E) {
+	t.AddRange(v, v+1)
+}
+
+// AddRange adds range [lo, hi) into t.
+func (t *TreeRangeSet[E]) AddRange(lo, hi E) {
+	if lo >= hi {
+		return
+	}
+
+	// Split the tree into three parts: Ranges before lo, Ranges that lie
+	// within [lo, hi], and Ranges after hi. The last Range of the first part
+	// may still overlap or adjoin lo, in which case it gets merged too.
+
+	left, rest := splitTree(t.root, func(r Range[E]) bool { return r.Low < lo })
+
+	if left != nil {
+		if last := left.last(); last.High >= lo {
+			left, _ = splitLast(left)
+			lo = last.Low
+			hi = max(hi, last.High)
+		}
+	}
+
+	middle, right := splitTree(rest, func(r Range[E]) bool { return r.Low <= hi })
+
+	if middle != nil {
+		hi = max(hi, middle.last().High)
+	}
+
+	t.root = joinTree(left, Range[E]{lo, hi}, right)
+}
+
+// Delete removes a single element from t.
+func (t *TreeRangeSet[E]) Delete(v E) {
+	t.DeleteRange(v, v+1)
+}
+
+// DeleteRange removes range [lo, hi) from t.
+func (t *TreeRangeSet[E]) DeleteRange(lo, hi E) {
+	if lo >= hi {
+		return
+	}
+
+	left, rest := splitTree(t.root, func(r Range[E]) bool { return r.Low < lo })
+	middle, right := splitTree(rest, func(r Range[E]) bool { return r.Low < hi })
+
+	if left != nil {
+		if last := left.last(); last.High > lo {
+			left, _ = splitLast(left)
+			left = joinTree(left, Range[E]{last.Low, lo}, nil)
+
+			if last.High > hi {
+				right = joinTree(nil, Range[E]{hi, last.High}, right)
+			}
+		}
+	}
+
+	if middle != nil {
+		if last := middle.last(); last.High > hi {
+			right = joinTree(nil, Range[E]{hi, last.High}, right)
+		}
+	}
+
+	t.root = joinTree2(left, right)
+}
+
+// Contains reports whether t contains a single element.
+func (t TreeRangeSet[E]) Contains(v E) bool {
+	return t.ContainsRange(v, v+1)
+}
+
+// ContainsRange reports whether t contains every element in range [lo, hi).
+func (t TreeRangeSet[E]) ContainsRange(lo, hi E) bool {
+	if lo >= hi {
+		return false
+	}
+
+	r, ok := t.root.floor(lo)
+
+	return ok && hi <= r.High
+}
+
+// Count returns the number of element in t.
+func (t TreeRangeSet[E]) Count() uint64 {
+	return t.root.getCount()
+}
+
+// Len returns the number of Ranges in t.
+func (t TreeRangeSet[E]) Len() int {
+	return t.root.getSize()
+}
+
+// Equal reports whether t is identical to other.
+func (t TreeRangeSet[E]) Equal(other TreeRangeSet[E]) bool {
+	if t.root == other.root {
+		return true
+	}
+
+	if t.Len() != other.Len() {
+		return false
+	}
+
+	next, stop := iter.Pull(other.Ranges())
+	defer stop()
+
+	for r := range t.Ranges() {
+		if r2, _ := next(); r != r2 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Extent returns the smallest Range that covers the whole set.
+//
+// If t is empty, Extent returns the zero value.
+func (t TreeRangeSet[E]) Extent() Range[E] {
+	if t.root == nil {
+		return Range[E]{}
+	}
+
+	return Range[E]{t.root.first().Low, t.root.last().High}
+}
+
+// Union returns the union of t and other.
+func (t TreeRangeSet[E]) Union(other TreeRangeSet[E]) TreeRangeSet[E] {
+	if t.Len() < other.Len() {
+		t, other = other, t
+	}
+
+	for r := range other.Ranges() {
+		t.AddRange(r.Low, r.High)
+	}
+
+	return t
+}
+
+// Intersection returns the intersection of t and other.
+func (t TreeRangeSet[E]) Intersection(other TreeRangeSet[E]) TreeRangeSet[E] {
+	return NewTreeRangeSet(t.RangeSet().Intersection(other.RangeSet()))
+}
+
+// Difference returns the subset of t that having all elements in other
+// excluded.
+func (t TreeRangeSet[E]) Difference(other TreeRangeSet[E]) TreeRangeSet[E] {
+	for r := range other.Ranges() {
+		t.DeleteRange(r.Low, r.High)
+	}
+
+	return t
+}
+
+// Complement returns the inverse of t.
+//
+// Complement of an empty set is a set that contains every E except one,
+// the maximum value of E.
+func (t TreeRangeSet[E]) Complement() TreeRangeSet[E] {
+	return NewTreeRangeSet(t.RangeSet().Complement())
+}
+
+// Ranges returns an iterator over Ranges in t, in ascending order.
+func (t TreeRangeSet[E]) Ranges() iter.Seq[Range[E]] {
+	return func(yield func(Range[E]) bool) {
+		t.root.walk(yield)
+	}
+}
+
+// treeNode is a node of an AVL tree, augmented with the number of Ranges
+// and the number of elements in the subtree.
+//
+// A treeNode is immutable once created.
+type treeNode[E Elem] struct {
+	r           Range[E]
+	left, right *treeNode[E]
+	height      int
+	size        int    // Number of Ranges in the subtree.
+	count       uint64 // Number of elements in the subtree.
+}
+
+func newTreeNode[E Elem](left *treeNode[E], r Range[E], right *treeNode[E]) *treeNode[E] {
+	return &treeNode[E]{
+		r:      r,
+		left:   left,
+		right:  right,
+		height: max(left.getHeight(), right.getHeight()) + 1,
+		size:   left.getSize() + 1 + right.getSize(),
+		count:  left.getCount() + rangeSize(r) + right.getCount(),
+	}
+}
+
+func (n *treeNode[E]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+func (n *treeNode[E]) getSize() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+func (n *treeNode[E]) getCount() uint64 {
+	if n == nil {
+		return 0
+	}
+
+	return n.count
+}
+
+func (n *treeNode[E]) first() Range[E] {
+	for n.left != nil {
+		n = n.left
+	}
+
+	return n.r
+}
+
+func (n *treeNode[E]) last() Range[E] {
+	for n.right != nil {
+		n = n.right
+	}
+
+	return n.r
+}
+
+// floor returns the last Range whose Low is less than or equal to v.
+func (n *treeNode[E]) floor(v E) (r Range[E], ok bool) {
+	for n != nil {
+		if n.r.Low <= v {
+			r, ok = n.r, true
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+
+	return
+}
+
+func (n *treeNode[E]) walk(yield func(Range[E]) bool) bool {
+	for n != nil {
+		if !n.left.walk(yield) || !yield(n.r) {
+			return false
+		}
+
+		n = n.right
+	}
+
+	return true
+}
+
+// buildTree builds a perfectly balanced tree from sorted Ranges.
+func buildTree[E Elem](s []Range[E]) *treeNode[E] {
+	if len(s) == 0 {
+		return nil
+	}
+
+	m := len(s) / 2
+
+	return newTreeNode(buildTree(s[:m]), s[m], buildTree(s[m+1:]))
+}
+
+// balanceTree creates a node from left, r and right, whose heights differ
+// by at most two, doing rotations as needed.
+func balanceTree[E Elem](left *treeNode[E], r Range[E], right *treeNode[E]) *treeNode[E] {
+	hl, hr := left.getHeight(), right.getHeight()
+
+	switch {
+	case hl > hr+1:
+		if left.left.getHeight() >= left.right.getHeight() {
+			return newTreeNode(left.left, left.r, newTreeNode(left.right, r, right))
+		}
+
+		lr := left.right
+
+		return newTreeNode(
+			newTreeNode(left.left, left.r, lr.left),
+			lr.r,
+			newTreeNode(lr.right, r, right),
+		)
+	case hr > hl+1:
+		if right.right.getHeight() >= right.left.getHeight() {
+			return newTreeNode(newTreeNode(left, r, right.left), right.r, right.right)
+		}
+
+		rl := right.left
+
+		return newTreeNode(
+			newTreeNode(left, r, rl.left),
+			rl.r,
+			newTreeNode(rl.right, right.r, right.right),
+		)
+	}
+
+	return newTreeNode(left, r, right)
+}
+
+// joinTree returns a tree containing every Range in left, r and every Range
+// in right, given that they are in ascending order.
+func joinTree[E Elem](left *treeNode[E], r Range[E], right *treeNode[E]) *treeNode[E] {
+	hl, hr := left.getHeight(), right.getHeight()
+
+	switch {
+	case hl > hr+1:
+		return balanceTree(left.left, left.r, joinTree(left.right, r, right))
+	case hr > hl+1:
+		return balanceTree(joinTree(left, r, right.left), right.r, right.right)
+	}
+
+	return newTreeNode(left, r, right)
+}
+
+// joinTree2 is like joinTree, but without a Range in between.
+func joinTree2[E Elem](left, right *treeNode[E]) *treeNode[E] {
+	if left == nil {
+		return right
+	}
+
+	left, last := splitLast(left)
+
+	return joinTree(left, last, right)
+}
+
+// splitTree splits n into two trees, the first of which contains every
+// Range that satisfies goesLeft. goesLeft must be monotonic: true for
+// a prefix of Ranges and false for the rest.
+func splitTree[E Elem](n *treeNode[E], goesLeft func(Range[E]) bool) (left, right *treeNode[E]) {
+	if n == nil {
+		return nil, nil
+	}
+
+	if goesLeft(n.r) {
+		l, r := splitTree(n.right, goesLeft)
+		return joinTree(n.left, n.r, l), r
+	}
+
+	l, r := splitTree(n.left, goesLeft)
+
+	return l, joinTree(r, n.r, n.right)
+}
+
+// splitLast removes the last Range from n, which must not be nil.
+func splitLast[E Elem](n *treeNode[E]) (*treeNode[E], Range[E]) {
+	if n.right == nil {
+		return n.left, n.r
+	}
+
+	right, last := splitLast(n.right)
+
+	return joinTree(n.left, n.r, right), last
+}
@@ -0,0 +1,134 @@
+package rangeset_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestTreeRangeSet(t *testing.T) {
+	type E int
+
+	addRange := func(s RangeSet[E], r Range[E]) RangeSet[E] {
+		tree := NewTreeRangeSet(s)
+		tree.AddRange(r.Low, r.High)
+		return tree.RangeSet()
+	}
+	deleteRange := func(s RangeSet[E], r Range[E]) RangeSet[E] {
+		tree := NewTreeRangeSet(s)
+		tree.DeleteRange(r.Low, r.High)
+		return tree.RangeSet()
+	}
+
+	testCases := []struct {
+		Result, Expected RangeSet[E]
+	}{
+		{addRange(RangeSet[E]{}, Range[E]{1, 4}), RangeSet[E]{{1, 4}}},
+		{addRange(RangeSet[E]{{1, 4}, {9, 12}}, Range[E]{5, 8}), RangeSet[E]{{1, 4}, {5, 8}, {9, 12}}},
+		{addRange(RangeSet[E]{{1, 4}, {9, 12}}, Range[E]{4, 8}), RangeSet[E]{{1, 8}, {9, 12}}},
+		{addRange(RangeSet[E]{{1, 4}, {9, 12}}, Range[E]{5, 9}), RangeSet[E]{{1, 4}, {5, 12}}},
+		{addRange(RangeSet[E]{{1, 4}, {9, 12}}, Range[E]{4, 9}), RangeSet[E]{{1, 12}}},
+		{addRange(RangeSet[E]{{1, 4}, {9, 12}}, Range[E]{2, 3}), RangeSet[E]{{1, 4}, {9, 12}}},
+		{addRange(RangeSet[E]{{1, 4}, {9, 12}}, Range[E]{12, 9}), RangeSet[E]{{1, 4}, {9, 12}}},
+		{deleteRange(RangeSet[E]{{1, 4}, {7, 10}, {13, 16}}, Range[E]{7, 10}), RangeSet[E]{{1, 4}, {13, 16}}},
+		{deleteRange(RangeSet[E]{{1, 4}, {7, 10}, {13, 16}}, Range[E]{8, 9}), RangeSet[E]{{1, 4}, {7, 8}, {9, 10}, {13, 16}}},
+		{deleteRange(RangeSet[E]{{1, 4}, {7, 10}, {13, 16}}, Range[E]{2, 15}), RangeSet[E]{{1, 2}, {15, 16}}},
+		{deleteRange(RangeSet[E]{{1, 4}, {7, 10}, {13, 16}}, Range[E]{0, 20}), RangeSet[E]{}},
+		{deleteRange(RangeSet[E]{{1, 4}, {7, 10}, {13, 16}}, Range[E]{4, 7}), RangeSet[E]{{1, 4}, {7, 10}, {13, 16}}},
+	}
+
+	for i, c := range testCases {
+		if !c.Result.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
+
+func TestTreeRangeSet_queries(t *testing.T) {
+	type E uint
+
+	s := NewTreeRangeSet(RangeSet[E]{{1, 3}, {5, 7}})
+
+	assertions := []bool{
+		!s.Contains(0),
+		s.Contains(1),
+		!s.Contains(3),
+		s.Contains(6),
+		s.ContainsRange(5, 7),
+		!s.ContainsRange(1, 7),
+		!s.ContainsRange(2, 2),
+		s.Count() == 4,
+		s.Len() == 2,
+		s.Extent() == Range[E]{1, 7},
+		TreeRangeSet[E]{}.Extent() == Range[E]{},
+		s.Equal(NewTreeRangeSet(RangeSet[E]{{1, 3}, {5, 7}})),
+		!s.Equal(NewTreeRangeSet(RangeSet[E]{{1, 3}, {5, 8}})),
+		s.Complement().RangeSet().Equal(RangeSet[E]{{0, 1}, {3, 5}, {7, ^E(0)}}),
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
+
+func TestTreeRangeSet_algebra(t *testing.T) {
+	type E int
+
+	a := NewTreeRangeSet(RangeSet[E]{{3, 11}, {13, 21}})
+	b := NewTreeRangeSet(RangeSet[E]{{1, 5}, {9, 15}, {19, 23}})
+
+	testCases := []struct {
+		Result, Expected RangeSet[E]
+	}{
+		{a.Union(b).RangeSet(), RangeSet[E]{{1, 23}}},
+		{a.Intersection(b).RangeSet(), RangeSet[E]{{3, 5}, {9, 11}, {13, 15}, {19, 21}}},
+		{a.Difference(b).RangeSet(), RangeSet[E]{{5, 9}, {15, 19}}},
+		{a.RangeSet(), RangeSet[E]{{3, 11}, {13, 21}}},
+		{b.RangeSet(), RangeSet[E]{{1, 5}, {9, 15}, {19, 23}}},
+	}
+
+	for i, c := range testCases {
+		if !c.Result.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Result)
+		}
+	}
+}
+
+func TestTreeRangeSet_random(t *testing.T) {
+	type E int16
+
+	rng := rand.New(rand.NewSource(1))
+
+	var (
+		s    RangeSet[E]
+		tree TreeRangeSet[E]
+	)
+
+	for i := 0; i < 5000; i++ {
+		lo := E(rng.Intn(2000))
+		hi := lo + E(rng.Intn(20))
+
+		if rng.Intn(3) == 0 {
+			s.DeleteRange(lo, hi)
+			tree.DeleteRange(lo, hi)
+		} else {
+			s.AddRange(lo, hi)
+			tree.AddRange(lo, hi)
+		}
+
+		if tree.Len() != len(s) || tree.Count() != s.Count() {
+			t.Fatalf("Step %v: want %v ranges and %v elements, but got %v and %v",
+				i, len(s), s.Count(), tree.Len(), tree.Count())
+		}
+	}
+
+	if !tree.RangeSet().Equal(s) {
+		t.Fatalf("want %v, but got %v", s, tree.RangeSet())
+	}
+}
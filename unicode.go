@@ -0,0 +1,124 @@
+package rangeset
+
+import "unicode"
+
+// FromRangeTable creates a RangeSet[rune] containing every rune in t.
+//
+// Malformed entries in t, i.e. those with a zero Stride or with Lo greater
+// than Hi, are ignored.
+func FromRangeTable(t *unicode.RangeTable) RangeSet[rune] {
+	var b Builder[rune]
+
+	for _, r := range t.R16 {
+		addStrided(&b, uint32(r.Lo), uint32(r.Hi), uint32(r.Stride))
+	}
+
+	for _, r := range t.R32 {
+		addStrided(&b, r.Lo, r.Hi, r.Stride)
+	}
+
+	return b.RangeSet()
+}
+
+func addStrided(b *Builder[rune], lo, hi, stride uint32) {
+	if stride == 0 || lo > hi {
+		return
+	}
+
+	if stride == 1 {
+		b.AddRange(rune(lo), rune(hi)+1)
+		return
+	}
+
+	for v := lo; ; v += stride {
+		b.Add(rune(v))
+
+		if hi-v < stride {
+			break
+		}
+	}
+}
+
+// ToRangeTable creates a unicode.RangeTable containing every rune in set
+// that is a valid Unicode code point, i.e. within [0, unicode.MaxRune].
+//
+// The table has the fewest entries possible, using strides to cover runes
+// spaced evenly apart, and has LatinOffset set, so unicode.Is works on it
+// as fast as on the standard library's tables.
+func ToRangeTable(set RangeSet[rune]) *unicode.RangeTable {
+	const maxR16 = 1<<16 - 1
+
+	t := new(unicode.RangeTable)
+
+	for _, e := range tableEntries(set.Intersection(FromRange[rune](0, maxR16+1))) {
+		t.R16 = append(t.R16, unicode.Range16{Lo: uint16(e.lo), Hi: uint16(e.hi), Stride: uint16(e.stride)})
+
+		if e.hi <= unicode.MaxLatin1 {
+			t.LatinOffset++
+		}
+	}
+
+	for _, e := range tableEntries(set.Intersection(FromRange[rune](maxR16+1, unicode.MaxRune+1))) {
+		t.R32 = append(t.R32, unicode.Range32{Lo: uint32(e.lo), Hi: uint32(e.hi), Stride: uint32(e.stride)})
+	}
+
+	return t
+}
+
+// A tableEntry is an entry of a unicode.RangeTable, covering every rune
+// from lo to hi (inclusive) that is a multiple of stride away from lo.
+type tableEntry struct {
+	lo, hi, stride rune
+}
+
+// tableEntries returns the fewest tableEntries that cover exactly set.
+//
+// A Range of three or more runes is always best covered by an entry of its
+// own. Shorter Ranges are broken into single runes, which are then grouped,
+// greedily from the left, into as long arithmetic progressions as possible.
+// Since a part of an arithmetic progression is still one, taking the
+// longest progression first never needs more entries than any other choice.
+func tableEntries(set RangeSet[rune]) []tableEntry {
+	var (
+		entries []tableEntry
+		singles []rune
+	)
+
+	flush := func() {
+		for i := 0; i < len(singles); {
+			if i+1 == len(singles) {
+				entries = append(entries, tableEntry{singles[i], singles[i], 1})
+				break
+			}
+
+			stride := singles[i+1] - singles[i]
+
+			j := i + 1
+			for j+1 < len(singles) && singles[j+1]-singles[j] == stride {
+				j++
+			}
+
+			entries = append(entries, tableEntry{singles[i], singles[j], stride})
+			i = j + 1
+		}
+
+		singles = singles[:0]
+	}
+
+	for _, r := range set {
+		if r.High-r.Low >= 3 {
+			flush()
+			entries = append(entries, tableEntry{r.Low, r.High - 1, 1})
+
+			continue
+		}
+
+		for v := r.Low; v < r.High; v++ {
+			singles = append(singles, v)
+		}
+	}
+
+	flush()
+
+	return entries
+}
@@ -0,0 +1,141 @@
+package rangeset_test
+
+import (
+	"testing"
+	"unicode"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestFromRangeTable(t *testing.T) {
+	table := &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 'A', Hi: 'Z', Stride: 1},
+			{Lo: 'a', Hi: 'e', Stride: 2},
+			{Lo: 'f', Hi: 'g', Stride: 1},
+		},
+		R32: []unicode.Range32{
+			{Lo: 0x10000, Hi: 0x10004, Stride: 4},
+		},
+	}
+
+	expected := RangeSet[rune]{{'A', 'Z' + 1}, {'a', 'b'}, {'c', 'd'}, {'e', 'h'}, {0x10000, 0x10001}, {0x10004, 0x10005}}
+
+	if s := FromRangeTable(table); !s.Equal(expected) {
+		t.Fatalf("want %v, but got %v", expected, s)
+	}
+
+	malformed := &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 1, Hi: 5, Stride: 0},
+			{Lo: 'a', Hi: 'c', Stride: 1},
+			{Lo: 'z', Hi: 'x', Stride: 1},
+		},
+		R32: []unicode.Range32{
+			{Lo: 0x10000, Hi: 0x10004, Stride: 0},
+			{Lo: 0x10004, Hi: 0x10000, Stride: 2},
+		},
+	}
+
+	expected = RangeSet[rune]{{'a', 'c' + 1}}
+
+	if s := FromRangeTable(malformed); !s.Equal(expected) {
+		t.Fatalf("want %v, but got %v", expected, s)
+	}
+}
+
+func TestToRangeTable(t *testing.T) {
+	testCases := []struct {
+		Input    RangeSet[rune]
+		Expected *unicode.RangeTable
+	}{
+		{
+			RangeSet[rune]{},
+			&unicode.RangeTable{},
+		},
+		{
+			RangeSet[rune]{{'0', '9' + 1}, {'A', 'B'}, {'C', 'D'}, {'E', 'F'}, {0x100, 0x102}},
+			&unicode.RangeTable{
+				R16: []unicode.Range16{
+					{Lo: '0', Hi: '9', Stride: 1},
+					{Lo: 'A', Hi: 'E', Stride: 2},
+					{Lo: 0x100, Hi: 0x101, Stride: 1},
+				},
+				LatinOffset: 2,
+			},
+		},
+		{
+			RangeSet[rune]{{1, 2}, {3, 4}, {5, 7}, {8, 9}},
+			&unicode.RangeTable{
+				R16: []unicode.Range16{
+					{Lo: 1, Hi: 5, Stride: 2},
+					{Lo: 6, Hi: 8, Stride: 2},
+				},
+				LatinOffset: 2,
+			},
+		},
+		{
+			RangeSet[rune]{{-5, 3}, {0xfffe, 0x10002}, {unicode.MaxRune, unicode.MaxRune + 10}},
+			&unicode.RangeTable{
+				R16: []unicode.Range16{
+					{Lo: 0, Hi: 2, Stride: 1},
+					{Lo: 0xfffe, Hi: 0xffff, Stride: 1},
+				},
+				R32: []unicode.Range32{
+					{Lo: 0x10000, Hi: 0x10001, Stride: 1},
+					{Lo: unicode.MaxRune, Hi: unicode.MaxRune, Stride: 1},
+				},
+				LatinOffset: 1,
+			},
+		},
+	}
+
+	for i, c := range testCases {
+		if result := ToRangeTable(c.Input); !equalRangeTable(result, c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %+v, but got %+v", i, c.Expected, result)
+		}
+	}
+}
+
+func TestToRangeTable_roundTrip(t *testing.T) {
+	for _, table := range []*unicode.RangeTable{unicode.Upper, unicode.Greek, unicode.Nd, unicode.White_Space} {
+		set := FromRangeTable(table)
+		result := ToRangeTable(set)
+
+		if !FromRangeTable(result).Equal(set) {
+			t.Fatalf("round trip changed the set")
+		}
+
+		if len(result.R16)+len(result.R32) > len(table.R16)+len(table.R32) {
+			t.Fatalf("want at most %v entries, but got %v",
+				len(table.R16)+len(table.R32), len(result.R16)+len(result.R32))
+		}
+
+		for r := rune(0); r <= 0x20000; r++ {
+			if unicode.Is(result, r) != unicode.Is(table, r) {
+				t.Fatalf("unicode.Is(%U) differs", r)
+			}
+		}
+	}
+}
+
+func equalRangeTable(t1, t2 *unicode.RangeTable) bool {
+	if len(t1.R16) != len(t2.R16) || len(t1.R32) != len(t2.R32) || t1.LatinOffset != t2.LatinOffset {
+		return false
+	}
+
+	for i := range t1.R16 {
+		if t1.R16[i] != t2.R16[i] {
+			return false
+		}
+	}
+
+	for i := range t1.R32 {
+		if t1.R32[i] != t2.R32[i] {
+			return false
+		}
+	}
+
+	return true
+}
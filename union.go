@@ -1,21 +1,102 @@
 package rangeset
 
-import "sort"
+import (
+	"container/heap"
+	"sort"
+)
 
 // Union returns the union of set and other.
 func (set RangeSet[E]) Union(other RangeSet[E]) RangeSet[E] {
-	return unionBuffer(set, other, nil)
+	return appendUnion(nil, set, other)
 }
 
 // Union returns the union of zero or more sets.
+//
+// Union merges all sets at once with a heap, which takes O(N log k) time,
+// where N is the total number of Ranges and k the number of sets.
 func Union[E Elem](sets ...RangeSet[E]) RangeSet[E] {
-	return combine(unionBuffer[E], sets...)
+	switch len(sets) {
+	case 0:
+		return nil
+	case 1:
+		// Always return a distinct set (unless it's nil).
+		return append(RangeSet[E](nil), sets[0]...)
+	case 2:
+		return appendUnion(nil, sets[0], sets[1])
+	}
+
+	h := make(unionHeap[E], 0, len(sets))
+
+	for _, s := range sets {
+		if len(s) > 0 {
+			h = append(h, s)
+		}
+	}
+
+	heap.Init(&h)
+
+	var res RangeSet[E]
+
+	for len(h) > 0 {
+		r := h[0][0]
+
+		if n := len(res); n > 0 && r.Low <= res[n-1].High {
+			if r.High > res[n-1].High {
+				res[n-1].High = r.High
+			}
+		} else {
+			res = append(res, r)
+		}
+
+		if h[0] = h[0][1:]; len(h[0]) > 0 {
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+
+	return res
+}
+
+// unionHeap is a min-heap of non-empty RangeSets ordered by their first
+// Ranges.
+type unionHeap[E Elem] []RangeSet[E]
+
+func (h unionHeap[E]) Len() int           { return len(h) }
+func (h unionHeap[E]) Less(i, j int) bool { return h[i][0].Low < h[j][0].Low }
+func (h unionHeap[E]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *unionHeap[E]) Push(x any)        { *h = append(*h, x.(RangeSet[E])) }
+
+func (h *unionHeap[E]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// AppendUnion appends the union of s1 and s2 to dst and returns the
+// extended buffer.
+//
+// dst must not share backing storage with s1 or s2, and the result is
+// a valid RangeSet only if dst is empty or ends before s1 and s2 begin.
+func AppendUnion[E Elem](dst, s1, s2 RangeSet[E]) RangeSet[E] {
+	return appendUnion(dst, s1, s2)
+}
+
+// UnionWith sets set to the union of set and other.
+//
+// UnionWith reuses the backing storage of set if its capacity is at least
+// len(set)+len(other).
+func (set *RangeSet[E]) UnionWith(other RangeSet[E]) {
+	set.inPlace(other, appendUnion[E])
 }
 
-// unionBuffer returns the union of s1 and s2, using buf as its initial
-// backing storage.
-func unionBuffer[E Elem](s1, s2, buf RangeSet[E]) RangeSet[E] {
-	res := buf[:0]
+// appendUnion appends the union of s1 and s2 to dst.
+//
+// appendUnion never writes past the Ranges it has already read, which
+// makes it usable by inPlace.
+func appendUnion[E Elem](dst, s1, s2 RangeSet[E]) RangeSet[E] {
+	res := dst
 
 	for {
 		if len(s1) < len(s2) {
@@ -1,6 +1,7 @@
 package rangeset_test
 
 import (
+	"math/rand"
 	"testing"
 
 	. "github.com/b97tsk/rangeset"
@@ -43,6 +44,15 @@ func TestUnion(t *testing.T) {
 			),
 			RangeSet[E]{{1, 23}},
 		},
+		{
+			Union(
+				RangeSet[E]{{20, 30}},
+				RangeSet[E]{},
+				RangeSet[E]{{1, 3}, {25, 40}},
+				RangeSet[E]{{3, 5}, {10, 12}},
+			),
+			RangeSet[E]{{1, 5}, {10, 12}, {20, 40}},
+		},
 		{Union[E](), RangeSet[E]{}},
 		{Union(RangeSet[E]{}), RangeSet[E]{}},
 		{
@@ -74,3 +84,30 @@ func TestUnion(t *testing.T) {
 		}
 	}
 }
+
+func TestUnion_random(t *testing.T) {
+	type E int
+
+	rng := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 200; n++ {
+		sets := make([]RangeSet[E], 3+rng.Intn(5))
+
+		for i := range sets {
+			for j := 0; j < 10; j++ {
+				lo := E(rng.Intn(500))
+				sets[i].AddRange(lo, lo+E(rng.Intn(10)))
+			}
+		}
+
+		var expected RangeSet[E]
+
+		for _, s := range sets {
+			expected = expected.Union(s)
+		}
+
+		if result := Union(sets...); !result.Equal(expected) {
+			t.Fatalf("Union(%v): want %v, but got %v", sets, expected, result)
+		}
+	}
+}
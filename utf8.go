@@ -0,0 +1,152 @@
+package rangeset
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// A UTF8Sequence matches the UTF-8 encodings of a set of runes, one Range
+// of bytes for each byte position. The n-th byte of an input must fall into
+// the n-th Range for the input to match.
+type UTF8Sequence []Range[byte]
+
+// Matches reports whether b, in its entirety, is matched by seq.
+func (seq UTF8Sequence) Matches(b []byte) bool {
+	if len(b) != len(seq) {
+		return false
+	}
+
+	for i, r := range seq {
+		if b[i] < r.Low || b[i] >= r.High {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns the string representation of seq,
+// e.g. "[E0][A0-BF][80-BF]".
+func (seq UTF8Sequence) String() string {
+	var b []byte
+
+	for _, r := range seq {
+		b = append(b, '[')
+		b = appendHexByte(b, r.Low)
+
+		if r.High-1 != r.Low {
+			b = append(b, '-')
+			b = appendHexByte(b, r.High-1)
+		}
+
+		b = append(b, ']')
+	}
+
+	return string(b)
+}
+
+func appendHexByte(b []byte, v byte) []byte {
+	const digits = "0123456789ABCDEF"
+	return append(b, digits[v>>4], digits[v&0xF])
+}
+
+// UTF8Sequences returns a list of UTF8Sequences that together match the
+// UTF-8 encodings of every rune in set, and nothing else.
+//
+// Runes that cannot be encoded in UTF-8, i.e. negative values, surrogates
+// and values greater than unicode.MaxRune, are ignored.
+//
+// The sequences are in ascending order of the runes they match, and no two
+// of them match the same input. The list is as short as splitting each
+// Range at UTF-8 length and continuation-byte boundaries allows, so that
+// it can be fed directly into a byte-oriented automaton.
+func UTF8Sequences(set RangeSet[rune]) []UTF8Sequence {
+	const (
+		surrogateMin = 0xD800
+		surrogateMax = 0xDFFF
+	)
+
+	set = set.Intersection(RangeSet[rune]{{0, surrogateMin}, {surrogateMax + 1, unicode.MaxRune + 1}})
+
+	var seqs []UTF8Sequence
+
+	for _, r := range set {
+		seqs = appendUTF8Sequences(seqs, r.Low, r.High-1)
+	}
+
+	return seqs
+}
+
+// appendUTF8Sequences appends to seqs the UTF8Sequences for runes from lo
+// to hi (inclusive), which must contain no surrogates.
+func appendUTF8Sequences(seqs []UTF8Sequence, lo, hi rune) []UTF8Sequence {
+	// Pieces above a split point are set aside in a stack and handled after
+	// the ones below, which keeps the output in ascending order.
+
+	var stack []Range[rune]
+
+	for {
+		if mid, ok := utf8SplitPoint(lo, hi); ok {
+			stack = append(stack, Range[rune]{mid, hi + 1})
+			hi = mid - 1
+
+			continue
+		}
+
+		var a, b [utf8.UTFMax]byte
+
+		n := utf8.EncodeRune(a[:], lo)
+		utf8.EncodeRune(b[:], hi)
+
+		seq := make(UTF8Sequence, n)
+
+		for i := range seq {
+			seq[i] = Range[byte]{a[i], b[i] + 1}
+		}
+
+		seqs = append(seqs, seq)
+
+		if len(stack) == 0 {
+			return seqs
+		}
+
+		r := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		lo, hi = r.Low, r.High-1
+	}
+}
+
+// utf8SplitPoint returns the first rune of the upper piece, if runes from
+// lo to hi (inclusive) cannot be described byte by byte by a single
+// UTF8Sequence and need to be split. That is the case when they encode to
+// different lengths, or when a continuation byte does not cover its full
+// range [80-BF] while a byte before it varies.
+func utf8SplitPoint(lo, hi rune) (rune, bool) {
+	for _, max := range [...]rune{utf8.RuneSelf - 1, 1<<11 - 1, 1<<16 - 1} {
+		if lo <= max && max < hi {
+			return max + 1, true
+		}
+	}
+
+	if hi < utf8.RuneSelf {
+		return 0, false
+	}
+
+	for i := 1; i < utf8.UTFMax; i++ {
+		m := rune(1)<<(6*i) - 1
+
+		if lo&^m == hi&^m {
+			continue
+		}
+
+		if lo&m != 0 {
+			return (lo | m) + 1, true
+		}
+
+		if hi&m != m {
+			return hi &^ m, true
+		}
+	}
+
+	return 0, false
+}
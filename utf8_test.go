@@ -0,0 +1,94 @@
+package rangeset_test
+
+import (
+	"math/rand"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	. "github.com/b97tsk/rangeset"
+)
+
+func TestUTF8Sequences(t *testing.T) {
+	testCases := []struct {
+		Input    RangeSet[rune]
+		Expected []string
+	}{
+		{RangeSet[rune]{}, nil},
+		{RangeSet[rune]{{'a', 'z' + 1}}, []string{"[61-7A]"}},
+		{RangeSet[rune]{{-10, 0x80}}, []string{"[00-7F]"}},
+		{RangeSet[rune]{{0x7F, 0x81}}, []string{"[7F]", "[C2][80]"}},
+		{
+			Universal[rune](),
+			[]string{
+				"[00-7F]",
+				"[C2-DF][80-BF]",
+				"[E0][A0-BF][80-BF]",
+				"[E1-EC][80-BF][80-BF]",
+				"[ED][80-9F][80-BF]",
+				"[EE-EF][80-BF][80-BF]",
+				"[F0][90-BF][80-BF][80-BF]",
+				"[F1-F3][80-BF][80-BF][80-BF]",
+				"[F4][80-8F][80-BF][80-BF]",
+			},
+		},
+		{
+			RangeSet[rune]{{0x0800, 0x0801}, {0xD7FF, 0xE001}},
+			[]string{"[E0][A0][80]", "[ED][9F][BF]", "[EE][80][80]"},
+		},
+		{
+			RangeSet[rune]{{0x07FF, 0x0900}},
+			[]string{"[DF][BF]", "[E0][A0-A3][80-BF]"},
+		},
+	}
+
+	for i, c := range testCases {
+		result := UTF8Sequences(c.Input)
+
+		ok := len(result) == len(c.Expected)
+
+		for j := 0; ok && j < len(result); j++ {
+			ok = result[j].String() == c.Expected[j]
+		}
+
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, result)
+		}
+	}
+}
+
+func TestUTF8Sequences_random(t *testing.T) {
+	rand := rand.New(rand.NewSource(1))
+
+	for k := 0; k < 4; k++ {
+		var set RangeSet[rune]
+
+		for j := 0; j < 8; j++ {
+			lo := rand.Int31n(unicode.MaxRune + 1)
+			set.AddRange(lo, lo+rand.Int31n(1<<(4*k+4)))
+		}
+
+		seqs := UTF8Sequences(set)
+
+		var b [utf8.UTFMax]byte
+
+		for r := rune(0); r <= unicode.MaxRune; r++ {
+			if !utf8.ValidRune(r) {
+				continue
+			}
+
+			n, matched := utf8.EncodeRune(b[:], r), 0
+
+			for _, seq := range seqs {
+				if seq.Matches(b[:n]) {
+					matched++
+				}
+			}
+
+			if set.Contains(r) && matched != 1 || !set.Contains(r) && matched != 0 {
+				t.Fatalf("Case %v: %U is matched by %v sequences", k, r, matched)
+			}
+		}
+	}
+}